@@ -0,0 +1,100 @@
+// Command import loads a catalogue of songs from an NDJSON or CSV file
+// through the bulk import endpoint of a running library server.
+//
+//	import -url http://localhost:8080 -columns group:Artist,name:Title songs.csv
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/Alina9496/library/pkg/api/v1"
+)
+
+func main() {
+	server := flag.String("url", "http://localhost:8080", "library server URL")
+	format := flag.String("format", "", "file format, ndjson or csv; by default the file extension")
+	columns := flag.String("columns", "", "CSV columns of the song fields, e.g. group:Artist,name:Title")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: %s [flags] file\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	path := flag.Arg(0)
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	var contentType string
+	switch *format {
+	case "ndjson", "jsonl":
+		contentType = "application/x-ndjson"
+	case "csv":
+		contentType = "text/csv"
+	default:
+		log.Fatalf("Unknown format %q, use -format ndjson or csv", *format)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatalf("Open error: %s", err)
+	}
+	defer file.Close()
+
+	report, err := upload(*server, contentType, *columns, file)
+	if report != nil {
+		for _, row := range report.Rows {
+			if row.Status != "created" {
+				fmt.Printf("row %d: %s: %s\n", row.Row, row.Status, row.Error)
+			}
+		}
+		fmt.Printf("created: %d, duplicate: %d, invalid: %d, failed: %d\n",
+			report.Created, report.Duplicate, report.Invalid, report.Failed)
+	}
+	if err != nil {
+		log.Fatalf("Import error: %s", err)
+	}
+}
+
+// upload streams the file to the import endpoint and returns its report.
+// The report of an import stopped by an error is returned with the error.
+func upload(server, contentType, columns string, body io.Reader) (*v1.ImportReport, error) {
+	u, err := url.JoinPath(server, "/api/v1/songs/import")
+	if err != nil {
+		return nil, err
+	}
+	if columns != "" {
+		u += "?" + url.Values{"columns": {columns}}.Encode()
+	}
+
+	resp, err := http.Post(u, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Response *v1.ImportReport `json:"response"`
+		Error    string           `json:"error"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	if resp.StatusCode != http.StatusOK {
+		return result.Response, fmt.Errorf("%s: %s", resp.Status, result.Error)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result.Response, nil
+}
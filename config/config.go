@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -9,10 +10,14 @@ import (
 type (
 	// Config -.
 	Config struct {
-		App  `yaml:"app"`
-		HTTP `yaml:"http"`
-		Log  `yaml:"logger"`
-		PG   `yaml:"postgres"`
+		App         `yaml:"app"`
+		HTTP        `yaml:"http"`
+		Log         `yaml:"logger"`
+		PG          `yaml:"postgres"`
+		SongInfo    `yaml:"song_info"`
+		Trash       `yaml:"trash"`
+		Idempotency `yaml:"idempotency"`
+		Search      `yaml:"search"`
 	}
 
 	// App -.
@@ -33,7 +38,34 @@ type (
 
 	// PG -.
 	PG struct {
-		URL string `env-required:"true" yaml:"PG_URL" env:"PG_URL"`
+		URL     string `env-required:"true" yaml:"PG_URL"   env:"PG_URL"`
+		PoolMax int    `env-default:"10"    yaml:"pool_max" env:"PG_POOL_MAX"`
+	}
+
+	// SongInfo -.
+	SongInfo struct {
+		URL        string        `env-required:"true" yaml:"url"         env:"SONG_INFO_URL"`
+		Timeout    time.Duration `env-default:"5s"    yaml:"timeout"     env:"SONG_INFO_TIMEOUT"`
+		Retries    int           `env-default:"2"     yaml:"retries"     env:"SONG_INFO_RETRIES"`
+		RetryDelay time.Duration `env-default:"200ms" yaml:"retry_delay" env:"SONG_INFO_RETRY_DELAY"`
+	}
+
+	// Trash -.
+	Trash struct {
+		Retention     time.Duration `env-default:"720h" yaml:"retention"      env:"TRASH_RETENTION"`
+		PurgeInterval time.Duration `env-default:"1h"   yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL"`
+	}
+
+	// Idempotency -.
+	Idempotency struct {
+		TTL   time.Duration `env-default:"24h" yaml:"ttl"   env:"IDEMPOTENCY_TTL"`
+		Lease time.Duration `env-default:"1m"  yaml:"lease" env:"IDEMPOTENCY_LEASE"`
+	}
+
+	// Search -.
+	Search struct {
+		Similarity float64       `env-default:"0.3" yaml:"similarity"  env:"SEARCH_SIMILARITY"`
+		SuggestTTL time.Duration `env-default:"10s" yaml:"suggest_ttl" env:"SEARCH_SUGGEST_TTL"`
 	}
 )
 
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+
+	v1 "github.com/Alina9496/library/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (s *Server) CreateAlbum(c *gin.Context) {
+	var a v1.Album
+	err := c.BindJSON(&a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	album, err := toDomainAlbum(a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	id, err := s.service.CreateAlbum(c.Request.Context(), album)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRespID(id))
+}
+
+// UpdateAlbum updates the album details present in the body, the others are
+// kept. The track list is changed by SetAlbumTracks.
+func (s *Server) UpdateAlbum(c *gin.Context) {
+	var a v1.AlbumPatch
+	err := c.BindJSON(&a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	patch, err := toAlbumPatch(a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	patch.ID, err = uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	err = s.service.UpdateAlbum(c.Request.Context(), patch)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+func (s *Server) SetAlbumTracks(c *gin.Context) {
+	var t v1.AlbumTracks
+	err := c.BindJSON(&t)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	songIDs, err := toTrackIDs(t.TrackIDs)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	err = s.service.SetAlbumTracks(c.Request.Context(), &id, songIDs)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+func (s *Server) DeleteAlbum(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	err = s.service.DeleteAlbum(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+func (s *Server) GetAlbum(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	album, err := s.service.GetAlbum(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toAlbumResponse(album)})
+}
+
+func (s *Server) GetAlbums(c *gin.Context) {
+	filter, err := toGetAlbumsRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	albums, err := s.service.GetAlbums(c.Request.Context(), filter)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toGetAlbumsResponse(albums)})
+}
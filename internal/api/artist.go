@@ -0,0 +1,110 @@
+package api
+
+import (
+	"net/http"
+
+	v1 "github.com/Alina9496/library/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (s *Server) CreateArtist(c *gin.Context) {
+	var a v1.Artist
+	err := c.BindJSON(&a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	artist, err := toDomainArtist(a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	id, err := s.service.CreateArtist(c.Request.Context(), artist)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toRespID(id))
+}
+
+// UpdateArtist updates the fields present in the body, the others are kept.
+func (s *Server) UpdateArtist(c *gin.Context) {
+	var a v1.ArtistPatch
+	err := c.BindJSON(&a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	patch, err := toArtistPatch(a)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	patch.ID, err = uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	err = s.service.UpdateArtist(c.Request.Context(), patch)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+func (s *Server) DeleteArtist(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	err = s.service.DeleteArtist(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+func (s *Server) GetArtist(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	artist, err := s.service.GetArtist(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toArtistResponse(artist)})
+}
+
+func (s *Server) GetArtists(c *gin.Context) {
+	filter, err := toGetArtistsRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	artists, err := s.service.GetArtists(c.Request.Context(), filter)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toGetArtistsResponse(artists)})
+}
@@ -3,21 +3,57 @@ package api
 import (
 	"errors"
 
+	"github.com/Alina9496/library/internal/service"
 	"github.com/gin-gonic/gin"
 )
 
 func (s *Server) errorResponse(c *gin.Context, code int, err error) {
-	c.JSON(code, map[string]string{"error": err.Error()})
+	c.JSON(code, toErrorResponse(err))
+}
+
+func toErrorResponse(err error) map[string]string {
+	var exists *service.SongExistsError
+	if errors.As(err, &exists) {
+		return map[string]string{"error": err.Error(), "id": exists.ID.String()}
+	}
+	return map[string]string{"error": err.Error()}
 }
 
 var (
 	ErrParsingCreateDate = errors.New("Error parsing date")
 	ErrParsingNumber     = errors.New("Error parsing number")
+	ErrParsingFlag       = errors.New("Error parsing flag")
+	ErrParsingRange      = errors.New("Error parsing range")
+	ErrParsingTranspose  = errors.New("Error parsing transpose")
+	ErrParsingLang       = errors.New("Error parsing language tag")
 	ErrParsingID         = errors.New("Error parsing id")
 	ErrNameIsEmpty       = errors.New("Name is empty")
 	ErrGroupIsEmpty      = errors.New("Group is empty")
 	ErrLinkNotCorrect    = errors.New("Link is not correct")
 	ErrTextIsEmpty       = errors.New("Text is empty")
+	ErrLabelTooLong      = errors.New("Label is too long")
+	ErrQueryIsEmpty      = errors.New("Query is empty")
+	ErrParsingCursor     = errors.New("Error parsing cursor")
+	ErrCursorWithOffset  = errors.New("Cursor and offset can't be used together")
+	ErrCursorWithSort    = errors.New("Cursor and sort can't be used together")
+	ErrParsingSort       = errors.New("Error parsing sort")
+	ErrParsingFacets     = errors.New("Error parsing facets")
+	ErrParsingYear       = errors.New("Error parsing year")
+	ErrParsingTime       = errors.New("Error parsing time")
+	ErrReleaseDateRange  = errors.New("Release date range is empty")
+	ErrParsingETag       = errors.New("Error parsing If-Match")
+	ErrIdempotencyKey    = errors.New("Idempotency-Key is too long")
+	ErrUnsupportedPatch  = errors.New("Unsupported patch content type")
+	ErrUnsupportedImport = errors.New("Unsupported import content type")
+	ErrParsingColumns    = errors.New("Error parsing columns")
+	ErrParsingCSV        = errors.New("Error parsing CSV row")
+	ErrParsingJSON       = errors.New("Error parsing JSON row")
+	ErrParsingFormat     = errors.New("Error parsing format")
+	ErrImportRowTooLong  = errors.New("Import row is too long")
+	ErrParsingRevision   = errors.New("Error parsing revision")
+	ErrTitleIsEmpty      = errors.New("Title is empty")
+	ErrDuplicateTrack    = errors.New("Song is repeated in the track list")
+	ErrTracksInPatch     = errors.New("Track list is changed by PUT /albums/:id/tracks")
 	errInvalidRequest    = errors.New("Incorrect parameters")
 	errInvalidText       = errors.New("Incorrect text")
 )
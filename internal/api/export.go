@@ -0,0 +1,185 @@
+package api
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/lyrics"
+	"github.com/gin-gonic/gin"
+)
+
+// exportErrorTrailer carries the error which stopped an export after the
+// response was started.
+const exportErrorTrailer = "X-Export-Error"
+
+type exportFormat string
+
+const (
+	exportNDJSON exportFormat = "ndjson"
+	exportCSV    exportFormat = "csv"
+	exportZip    exportFormat = "zip"
+)
+
+// ExportSongs streams the songs matching the filters of GetSongs with their
+// full lyrics as NDJSON, CSV or a ZIP archive of JSON files. The songs are
+// written as they are read, so an error after the first song can only be
+// reported in the X-Export-Error trailer.
+func (s *Server) ExportSongs(c *gin.Context) {
+	filter, format, err := toExportRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	disableTimeouts(c)
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", format.contentType())
+	header.Set("Content-Disposition", `attachment; filename="songs.`+string(format)+`"`)
+	header.Set("Trailer", exportErrorTrailer)
+
+	w := newExportWriter(format, c.Writer)
+	err = s.service.ExportSongs(c.Request.Context(), filter, w.Write)
+	if err == nil {
+		err = w.Close()
+	}
+	if err != nil {
+		if !c.Writer.Written() {
+			header.Del("Content-Type")
+			header.Del("Content-Disposition")
+			header.Del("Trailer")
+			s.errorResponse(c, errToHttpStatus(err), err)
+			return
+		}
+		header.Set(exportErrorTrailer, err.Error())
+	}
+}
+
+func (f exportFormat) contentType() string {
+	switch f {
+	case exportCSV:
+		return "text/csv; charset=utf-8"
+	case exportZip:
+		return "application/zip"
+	default:
+		return contentTypeNDJSON
+	}
+}
+
+// disableTimeouts lifts the server read and write timeouts from a request
+// which streams a whole catalogue.
+func disableTimeouts(c *gin.Context) {
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+	_ = rc.SetWriteDeadline(time.Time{})
+}
+
+// exportWriter writes the exported songs one by one,
+// Close completes the output.
+type exportWriter interface {
+	Write(song *domain.Song) error
+	Close() error
+}
+
+func newExportWriter(format exportFormat, w io.Writer) exportWriter {
+	switch format {
+	case exportCSV:
+		return &csvExport{w: csv.NewWriter(w)}
+	case exportZip:
+		return &zipExport{w: zip.NewWriter(w)}
+	default:
+		return &ndjsonExport{enc: json.NewEncoder(w)}
+	}
+}
+
+// ndjsonExport writes each song as a line of JSON.
+type ndjsonExport struct {
+	enc *json.Encoder
+}
+
+func (e *ndjsonExport) Write(song *domain.Song) error {
+	return e.enc.Encode(toExportSong(song))
+}
+
+func (e *ndjsonExport) Close() error {
+	return nil
+}
+
+// csvExportHeader names the columns like the fields of an import,
+// so that the file can be imported back.
+var csvExportHeader = []string{"id", "group", "name", "link", "release_date", "text", "version", "created_at", "updated_at"}
+
+// csvExport writes each song as a CSV row, the lyrics are plain text with
+// the section headers. An import splits the text again, which only comes
+// close to the sections: a label that is not a section name such as "Final"
+// is read as a line of the lyrics and the type of its section is guessed,
+// a verse without a label gets one such as "Verse 2", and the chords and
+// timing are lost. The NDJSON export imports back with the same sections.
+type csvExport struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvExport) Write(song *domain.Song) error {
+	if !e.header {
+		e.header = true
+		err := e.w.Write(csvExportHeader)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.w.Write([]string{
+		song.ID.String(),
+		song.Group,
+		song.Name,
+		song.Link,
+		song.ReleaseDate.Format(time.DateOnly),
+		lyrics.Render(song.Text, lyrics.Options{Format: lyrics.Plain, Headers: true}),
+		strconv.Itoa(song.Version),
+		song.CreatedAt.Format(time.RFC3339),
+		song.UpdatedAt.Format(time.RFC3339),
+	})
+}
+
+func (e *csvExport) Close() error {
+	if !e.header {
+		e.header = true
+		err := e.w.Write(csvExportHeader)
+		if err != nil {
+			return err
+		}
+	}
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// zipExport writes each song to a JSON file of the archive named by its ID.
+type zipExport struct {
+	w *zip.Writer
+}
+
+func (e *zipExport) Write(song *domain.Song) error {
+	f, err := e.w.CreateHeader(&zip.FileHeader{
+		Name:     "songs/" + song.ID.String() + ".json",
+		Method:   zip.Deflate,
+		Modified: song.UpdatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toExportSong(song))
+}
+
+func (e *zipExport) Close() error {
+	return e.w.Close()
+}
@@ -0,0 +1,262 @@
+package api
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/lyrics"
+	v1 "github.com/Alina9496/library/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+)
+
+const importBatchSize = 500
+
+// ImportSongs creates the songs of an NDJSON or CSV body. The rows are
+// validated like the body of Create and inserted in batches, a batch is
+// committed before the next one is read. The report has the outcome of
+// each row, when the import stops on an error it is returned with the error:
+// the rows of the batch that was not committed and the row the error was
+// read at are failed.
+func (s *Server) ImportSongs(c *gin.Context) {
+	source, err := newSongSource(c.ContentType(), c.Query("columns"), c.Request.Body)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	disableTimeouts(c)
+
+	report := v1.ImportReport{Rows: make([]v1.ImportRow, 0)}
+	rows := make([]int, 0, importBatchSize)
+	songs := make([]domain.Song, 0, importBatchSize)
+	flush := func() error {
+		if len(songs) == 0 {
+			return nil
+		}
+		results, err := s.service.ImportSongs(c.Request.Context(), songs)
+		if err != nil {
+			return err
+		}
+		for i, result := range results {
+			addImportRow(&report, rows[i], result)
+		}
+		rows, songs = rows[:0], songs[:0]
+		return nil
+	}
+	abort := func(err error) {
+		for _, row := range rows {
+			addImportRow(&report, row, domain.ImportResult{Status: domain.ImportFailed, Err: err})
+		}
+		c.JSON(errToHttpStatus(err), map[string]any{"error": err.Error(), "response": report})
+	}
+
+	for {
+		record, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// the songs read before the failing row are still created
+			if flushErr := flush(); flushErr != nil {
+				err = flushErr
+			}
+			rows = append(rows, record.row)
+			abort(err)
+			return
+		}
+
+		var song *domain.Song
+		err = record.err
+		if err == nil {
+			song, err = toDomainSong(record.song)
+		}
+		if err != nil {
+			addImportRow(&report, record.row, domain.ImportResult{Status: domain.ImportInvalid, Err: err})
+			continue
+		}
+		rows = append(rows, record.row)
+		songs = append(songs, *song)
+
+		if len(songs) == importBatchSize {
+			err = flush()
+			if err != nil {
+				abort(err)
+				return
+			}
+		}
+	}
+
+	err = flush()
+	if err != nil {
+		abort(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": report})
+}
+
+// importRecord is a song of an import body. Row is the line of the body where
+// the song starts, err is set when the song could not be read.
+type importRecord struct {
+	row  int
+	song v1.Song
+	err  error
+}
+
+// songSource reads the songs of an import body one by one, the error is
+// io.EOF at the end of the body. With another error the record has the row
+// the error was read at.
+type songSource interface {
+	Next() (importRecord, error)
+}
+
+func newSongSource(contentType, columns string, body io.Reader) (songSource, error) {
+	switch contentType {
+	case contentTypeNDJSON:
+		scanner := bufio.NewScanner(body)
+		scanner.Buffer(nil, maxUploadSize)
+		return &ndjsonSource{scanner: scanner}, nil
+	case contentTypeCSV:
+		return newCSVSource(body, columns)
+	default:
+		return nil, ErrUnsupportedImport
+	}
+}
+
+// ndjsonSource reads a song in the JSON of Create from each line,
+// blank lines are skipped.
+type ndjsonSource struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+func (s *ndjsonSource) Next() (importRecord, error) {
+	for s.scanner.Scan() {
+		s.line++
+		line := bytes.TrimSpace(s.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		record := importRecord{row: s.line}
+		if json.Unmarshal(line, &record.song) != nil {
+			record.err = ErrParsingJSON
+		}
+		return record, nil
+	}
+
+	if err := s.scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return importRecord{row: s.line + 1}, ErrImportRowTooLong
+		}
+		return importRecord{row: s.line + 1}, err
+	}
+	return importRecord{}, io.EOF
+}
+
+// importFields are the song fields of a CSV row, named like the JSON of Create.
+var importFields = []string{
+	"group",
+	"artist_id",
+	"album_id",
+	"featured_artist_ids",
+	"name",
+	"link",
+	"release_date",
+	"text",
+}
+
+// csvSource reads a song from each CSV row. The header names the columns,
+// by default a column has the name of its field. The text is plain lyrics
+// split into sections like in SplitLyrics, the featured artists are separated
+// by commas or semicolons. Next is the line after the last row read.
+type csvSource struct {
+	reader *csv.Reader
+	index  map[string]int
+	next   int
+}
+
+func newCSVSource(body io.Reader, columns string) (*csvSource, error) {
+	mapping, err := toImportColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return nil, ErrParsingColumns
+	}
+
+	position := make(map[string]int, len(header))
+	for i, name := range header {
+		position[strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF"))] = i
+	}
+
+	index := make(map[string]int, len(importFields))
+	for _, field := range importFields {
+		column, mapped := mapping[field]
+		if !mapped {
+			column = field
+		}
+		i, ok := position[column]
+		if !ok {
+			if mapped {
+				return nil, ErrParsingColumns
+			}
+			continue
+		}
+		index[field] = i
+	}
+
+	return &csvSource{reader: reader, index: index, next: 2}, nil
+}
+
+func (s *csvSource) Next() (importRecord, error) {
+	values, err := s.reader.Read()
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			s.next = parseErr.Line + 1
+			return importRecord{row: parseErr.StartLine, err: ErrParsingCSV}, nil
+		}
+		return importRecord{row: s.next}, err
+	}
+
+	row, _ := s.reader.FieldPos(0)
+	last, _ := s.reader.FieldPos(len(values) - 1)
+	s.next = last + strings.Count(values[len(values)-1], "\n") + 1
+	value := func(field string) string {
+		i, ok := s.index[field]
+		if !ok || i >= len(values) {
+			return ""
+		}
+		return strings.TrimSpace(values[i])
+	}
+
+	song := v1.Song{
+		Group:       value("group"),
+		ArtistID:    value("artist_id"),
+		AlbumID:     value("album_id"),
+		Name:        value("name"),
+		Link:        value("link"),
+		ReleaseDate: value("release_date"),
+		FeaturedArtistIDs: strings.FieldsFunc(value("featured_artist_ids"), func(r rune) bool {
+			return r == ',' || r == ';' || unicode.IsSpace(r)
+		}),
+	}
+	if text := value("text"); text != "" {
+		song.Text = toSongTextResponse(lyrics.Split(text))
+	}
+
+	return importRecord{row: row, song: song}, nil
+}
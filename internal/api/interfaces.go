@@ -9,8 +9,42 @@ import (
 
 type Service interface {
 	Create(ctx context.Context, song *domain.Song) (*uuid.UUID, error)
-	Update(ctx context.Context, song *domain.Song) error
-	Delete(ctx context.Context, id *uuid.UUID) error
-	GetTextSong(ctx context.Context, filter *domain.SongRequest) (string, error)
-	GetSongs(ctx context.Context, filter *domain.SongRequest) ([]domain.Song, error)
+	Patch(ctx context.Context, id *uuid.UUID, version int, apply func(song *domain.Song) error) (int, error)
+	Delete(ctx context.Context, id *uuid.UUID, version int) error
+	GetTextSong(ctx context.Context, filter *domain.SongRequest) (*domain.SongTextResult, error)
+	GetSong(ctx context.Context, id *uuid.UUID) (*domain.Song, error)
+	GetSongs(ctx context.Context, filter *domain.SongRequest) (*domain.SongList, error)
+	SearchSongs(ctx context.Context, filter *domain.SearchRequest) ([]domain.SearchResult, error)
+	Suggest(ctx context.Context, filter *domain.SuggestRequest) ([]domain.Suggestion, error)
+	ImportSongs(ctx context.Context, songs []domain.Song) ([]domain.ImportResult, error)
+	ExportSongs(ctx context.Context, filter *domain.SongRequest, fn func(song *domain.Song) error) error
+
+	GetTrash(ctx context.Context, filter *domain.TrashRequest) ([]domain.Song, error)
+	Restore(ctx context.Context, id *uuid.UUID) error
+	Purge(ctx context.Context, id *uuid.UUID) error
+
+	GetRevisions(ctx context.Context, id *uuid.UUID) ([]domain.SongRevision, error)
+	GetRevision(ctx context.Context, id *uuid.UUID, revision int) (*domain.SongRevision, error)
+	RollbackRevision(ctx context.Context, id *uuid.UUID, revision int) error
+
+	CreateArtist(ctx context.Context, artist *domain.Artist) (*uuid.UUID, error)
+	UpdateArtist(ctx context.Context, patch *domain.ArtistPatch) error
+	DeleteArtist(ctx context.Context, id *uuid.UUID) error
+	GetArtist(ctx context.Context, id *uuid.UUID) (*domain.Artist, error)
+	GetArtists(ctx context.Context, filter *domain.ArtistRequest) ([]domain.Artist, error)
+
+	CreateAlbum(ctx context.Context, album *domain.Album) (*uuid.UUID, error)
+	UpdateAlbum(ctx context.Context, patch *domain.AlbumPatch) error
+	DeleteAlbum(ctx context.Context, id *uuid.UUID) error
+	GetAlbum(ctx context.Context, id *uuid.UUID) (*domain.Album, error)
+	GetAlbums(ctx context.Context, filter *domain.AlbumRequest) ([]domain.Album, error)
+	SetAlbumTracks(ctx context.Context, albumID *uuid.UUID, songIDs []uuid.UUID) error
+
+	CreateTranslation(ctx context.Context, t *domain.Translation) error
+	UpdateTranslation(ctx context.Context, t *domain.Translation) error
+	GetTranslations(ctx context.Context, id *uuid.UUID) ([]domain.Translation, error)
+
+	BeginIdempotent(ctx context.Context, key, requestHash string) (uuid.UUID, *domain.IdempotencyKey, error)
+	CompleteIdempotent(ctx context.Context, key string, owner uuid.UUID, status int, response []byte) error
+	ReleaseIdempotent(ctx context.Context, key string, owner uuid.UUID) error
 }
@@ -1,103 +1,557 @@
 package api
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"html"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/lyrics"
+	"github.com/Alina9496/library/internal/patch"
 	service "github.com/Alina9496/library/internal/service"
 	v1 "github.com/Alina9496/library/pkg/api/v1"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	maxLabelLength          = 100
+	maxUploadSize           = 1 << 20
+	maxTranspose            = 11
+
+	defaultSearchLimit = 10
+	maxSearchLimit     = 100
+
+	maxSongsLimit = 100
+
+	defaultArtistLimit = 10
+	maxArtistLimit     = 100
+
+	defaultSuggestLimit = 10
+	maxSuggestLimit     = 50
+	maxSuggestQuery     = 100
+
+	cursorSeparator = "|"
 )
 
 func toDomainSong(song v1.Song) (*domain.Song, error) {
 	if song.Name == "" {
 		return nil, ErrNameIsEmpty
 	}
-	if song.Group == "" {
+	if song.Group == "" && song.ArtistID == "" && song.AlbumID == "" {
 		return nil, ErrGroupIsEmpty
 	}
 
-	u, err := url.Parse(song.Link)
-	if err != nil || u.Scheme == "" || u.Host == "" {
-		return nil, ErrLinkNotCorrect
+	var artistID uuid.UUID
+	if song.ArtistID != "" {
+		var err error
+		artistID, err = uuid.Parse(song.ArtistID)
+		if err != nil {
+			return nil, ErrParsingID
+		}
 	}
 
-	parsedDate, err := time.Parse(time.DateOnly, song.ReleaseDate)
-	if err != nil {
-		return nil, ErrParsingCreateDate
+	var albumID uuid.UUID
+	if song.AlbumID != "" {
+		var err error
+		albumID, err = uuid.Parse(song.AlbumID)
+		if err != nil {
+			return nil, ErrParsingID
+		}
+	}
+
+	var featured []uuid.UUID
+	for _, val := range song.FeaturedArtistIDs {
+		id, err := uuid.Parse(val)
+		if err != nil {
+			return nil, ErrParsingID
+		}
+		featured = append(featured, id)
+	}
+
+	if song.Link != "" {
+		u, err := url.Parse(song.Link)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, ErrLinkNotCorrect
+		}
+	}
+
+	var parsedDate time.Time
+	if song.ReleaseDate != "" {
+		var err error
+		parsedDate, err = time.Parse(time.DateOnly, song.ReleaseDate)
+		if err != nil {
+			return nil, ErrParsingCreateDate
+		}
 	}
 
 	songText := make(domain.SongText, 0, len(song.Text))
 	for _, val := range song.Text {
-		if val.Type == "" || val.Text == "" {
-			return nil, ErrTextIsEmpty
-		}
 		songText = append(songText, domain.SongItem{
-			Type: domain.TypeSongItem(val.Type),
-			Text: val.Text,
+			Type:  domain.TypeSongItem(val.Type),
+			Label: strings.TrimSpace(val.Label),
+			Text:  val.Text,
 		})
 	}
 
-	if !songText.IsValidType() {
-		return nil, errInvalidText
+	err := validateText(songText)
+	if err != nil {
+		return nil, err
 	}
 
 	return &domain.Song{
-		Text:        songText,
-		Name:        song.Name,
-		Group:       song.Group,
-		ReleaseDate: parsedDate,
-		Link:        song.Link,
+		Text:              songText,
+		Name:              song.Name,
+		Group:             song.Group,
+		ArtistID:          artistID,
+		AlbumID:           albumID,
+		FeaturedArtistIDs: featured,
+		ReleaseDate:       parsedDate,
+		Link:              song.Link,
 	}, nil
 }
 
+// validateText checks the sections of the lyrics from any source: every
+// section has a known type and text, and a label of limited length.
+func validateText(text domain.SongText) error {
+	for _, item := range text {
+		if item.Type == "" || item.Text == "" {
+			return ErrTextIsEmpty
+		}
+		if utf8.RuneCountInString(item.Label) > maxLabelLength {
+			return ErrLabelTooLong
+		}
+	}
+	if !text.IsValidType() {
+		return errInvalidText
+	}
+	return nil
+}
+
+// toDomainFullSong converts a song which must carry all details itself,
+// the song info provider is used only on create.
+func toDomainFullSong(song v1.Song) (*domain.Song, error) {
+	result, err := toDomainSong(song)
+	if err != nil {
+		return nil, err
+	}
+	if result.Link == "" {
+		return nil, ErrLinkNotCorrect
+	}
+	if result.ReleaseDate.IsZero() {
+		return nil, ErrParsingCreateDate
+	}
+	return result, nil
+}
+
+const (
+	contentTypeJSON       = "application/json"
+	contentTypeMergePatch = "application/merge-patch+json"
+	contentTypeJSONPatch  = "application/json-patch+json"
+	contentTypeChordPro   = "text/x-chordpro"
+	contentTypePlainText  = "text/plain"
+	contentTypeNDJSON     = "application/x-ndjson"
+	contentTypeCSV        = "text/csv"
+)
+
+// toPatchFunc selects the patch format by the content type,
+// a plain JSON body is a merge patch.
+func toPatchFunc(contentType string) (func(doc, patch []byte) ([]byte, error), error) {
+	switch contentType {
+	case contentTypeJSON, contentTypeMergePatch:
+		return patch.MergePatch, nil
+	case contentTypeJSONPatch:
+		return patch.JSONPatch, nil
+	default:
+		return nil, ErrUnsupportedPatch
+	}
+}
+
+// toPatchedSong applies the patch to the JSON form of the song
+// and validates the result as a whole song.
+func toPatchedSong(song *domain.Song, body []byte, apply func(doc, patch []byte) ([]byte, error)) error {
+	current := toSongResponse(song)
+	current.ID, current.Version = "", 0
+	current.CreatedAt, current.UpdatedAt = "", ""
+	doc, err := json.Marshal(current)
+	if err != nil {
+		return err
+	}
+
+	doc, err = apply(doc, body)
+	if err != nil {
+		return err
+	}
+
+	var sg v1.Song
+	d := json.NewDecoder(bytes.NewReader(doc))
+	d.DisallowUnknownFields()
+	err = d.Decode(&sg)
+	if err != nil {
+		return fmt.Errorf("%w: %w", patch.ErrInvalidPatch, err)
+	}
+
+	result, err := toDomainFullSong(sg)
+	if err != nil {
+		return err
+	}
+
+	result.ID = song.ID
+	*song = *result
+	return nil
+}
+
+// toIfMatch parses the If-Match header holding the song version as a strong
+// entity tag. Zero means any version.
+func toIfMatch(header string) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	tag, ok := strings.CutPrefix(header, `"`)
+	if ok {
+		tag, ok = strings.CutSuffix(tag, `"`)
+	}
+	if !ok {
+		return 0, ErrParsingETag
+	}
+
+	version, err := strconv.Atoi(tag)
+	if err != nil || version <= 0 {
+		return 0, ErrParsingETag
+	}
+	return version, nil
+}
+
+func toETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+func toIdempotencyKey(header string) (string, error) {
+	key := strings.TrimSpace(header)
+	if len(key) > maxIdempotencyKeyLength {
+		return "", ErrIdempotencyKey
+	}
+	return key, nil
+}
+
+// toRequestHash identifies the request sent with an idempotency key by its
+// content type, the body and, for plain text, the group and the name
+// from the query.
+func toRequestHash(c *gin.Context, body []byte) string {
+	contentType := c.ContentType()
+	parts := []string{contentType}
+	if contentType == contentTypePlainText {
+		parts = append(parts, c.Query("group"), c.Query("name"))
+	}
+
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 func toRespID(id *uuid.UUID) v1.RespID {
 	return v1.RespID{
 		ID: id.String(),
 	}
 }
 
+func toLyricsRequest(c *gin.Context) (*uuid.UUID, lyrics.Options, error) {
+	opts := lyrics.Options{Headers: true}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, opts, ErrParsingID
+	}
+
+	opts.Format, err = lyrics.ParseFormat(c.Query("format"))
+	if err != nil {
+		return nil, opts, err
+	}
+
+	opts.Headers, err = toFlag(c.Query("headers"), opts.Headers)
+	if err != nil {
+		return nil, opts, err
+	}
+	opts.Collapse, err = toFlag(c.Query("collapse"), opts.Collapse)
+	if err != nil {
+		return nil, opts, err
+	}
+
+	return &id, opts, nil
+}
+
+// toFlag parses the boolean query parameter, the empty one is def.
+func toFlag(value string, def bool) (bool, error) {
+	if value == "" {
+		return def, nil
+	}
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, ErrParsingFlag
+	}
+	return flag, nil
+}
+
+// toTranspose parses the number of semitones to transpose the chords by.
+func toTranspose(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	semitones, err := strconv.Atoi(value)
+	if err != nil || semitones < -maxTranspose || semitones > maxTranspose {
+		return 0, ErrParsingTranspose
+	}
+	return semitones, nil
+}
+
+// toChordProSong parses a ChordPro file of a new song,
+// the title and artist directives are required.
+func toChordProSong(body []byte) (*domain.Song, error) {
+	song, err := toChordPro(body)
+	if err != nil {
+		return nil, err
+	}
+	if song.Name == "" {
+		return nil, ErrNameIsEmpty
+	}
+	if song.Group == "" {
+		return nil, ErrGroupIsEmpty
+	}
+	return song, nil
+}
+
+// toChordPro parses the ChordPro file, its lyrics are checked
+// as the lyrics of a JSON body.
+func toChordPro(body []byte) (*domain.Song, error) {
+	song, err := lyrics.ParseChordPro(body)
+	if err != nil {
+		return nil, err
+	}
+	err = validateText(song.Text)
+	if err != nil {
+		return nil, err
+	}
+	return song, nil
+}
+
+// toPlainTextSong splits plain lyrics of a new song into sections,
+// the group and the name come from the query.
+func toPlainTextSong(group, name string, body []byte) (*domain.Song, error) {
+	if name == "" {
+		return nil, ErrNameIsEmpty
+	}
+	if group == "" {
+		return nil, ErrGroupIsEmpty
+	}
+	text := lyrics.Split(string(body))
+	if len(text) == 0 {
+		return nil, ErrTextIsEmpty
+	}
+	err := validateText(text)
+	if err != nil {
+		return nil, err
+	}
+	return &domain.Song{
+		Name:  name,
+		Group: group,
+		Text:  text,
+	}, nil
+}
+
+// toImportColumns parses the mapping of the song fields to the CSV columns
+// in the form "group:Artist,name:Title".
+func toImportColumns(value string) (map[string]string, error) {
+	columns := make(map[string]string)
+	if value == "" {
+		return columns, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		field, column, ok := strings.Cut(pair, ":")
+		field, column = strings.TrimSpace(field), strings.TrimSpace(column)
+		if !ok || column == "" || !slices.Contains(importFields, field) {
+			return nil, ErrParsingColumns
+		}
+		if _, ok := columns[field]; ok {
+			return nil, ErrParsingColumns
+		}
+		columns[field] = column
+	}
+	return columns, nil
+}
+
+// addImportRow adds the outcome of the row to the import report.
+func addImportRow(report *v1.ImportReport, row int, result domain.ImportResult) {
+	importRow := v1.ImportRow{Row: row, Status: string(result.Status)}
+	switch result.Status {
+	case domain.ImportCreated:
+		report.Created++
+		importRow.ID = result.ID.String()
+	case domain.ImportDuplicate:
+		report.Duplicate++
+	case domain.ImportFailed:
+		report.Failed++
+	default:
+		report.Invalid++
+	}
+	if result.Err != nil {
+		importRow.Error = result.Err.Error()
+	}
+	report.Rows = append(report.Rows, importRow)
+}
+
+// toGetTextSongRequest parses the address of the lyrics part: the section
+// type, the index or range of sections counted within the type or overall and
+// the line range inside a single section. The legacy offset is the index of a verse.
 func toGetTextSongRequest(c *gin.Context) (*domain.SongRequest, error) {
-	if c.Query("group") == "" || c.Query("name") == "" || c.Query("offset") == "" {
+	if c.Query("group") == "" || c.Query("name") == "" {
 		return nil, errInvalidRequest
 	}
 
-	offset, err := strconv.Atoi(c.Query("offset"))
-	if err != nil {
-		return nil, ErrParsingNumber
+	var addr domain.TextAddress
+	if section := c.Query("section"); section != "" {
+		addr.Section = domain.TypeSongItem(section)
+		if !addr.Section.IsValid() {
+			return nil, errInvalidText
+		}
+	}
+
+	var err error
+	switch offset, index := c.Query("offset"), c.Query("index"); {
+	case offset != "" && index != "":
+		return nil, errInvalidRequest
+	case offset != "":
+		addr.From, err = strconv.Atoi(offset)
+		if err != nil {
+			return nil, ErrParsingNumber
+		}
+		if addr.From < 1 {
+			return nil, ErrParsingRange
+		}
+		if addr.Section == "" {
+			addr.Section = domain.Verse
+		}
+	case index != "":
+		addr.From, addr.To, err = toRange(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if lines := c.Query("lines"); lines != "" {
+		if addr.From != addr.To && addr.To != 0 {
+			return nil, ErrParsingRange
+		}
+		addr.LineFrom, addr.LineTo, err = toRange(lines)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var lang string
+	if value := c.Query("lang"); value != "" {
+		lang, err = toLang(value)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return &domain.SongRequest{
-		Group:  c.Query("group"),
-		Name:   c.Query("name"),
-		Offset: offset,
+		Group: c.Query("group"),
+		Name:  c.Query("name"),
+		Text:  addr,
+		Lang:  lang,
 	}, nil
 }
 
+// toRange parses "N" or "N-M", indexes start from one.
+func toRange(value string) (int, int, error) {
+	first, last, found := strings.Cut(value, "-")
+	from, err := strconv.Atoi(first)
+	if err != nil || from < 1 {
+		return 0, 0, ErrParsingRange
+	}
+	if !found {
+		return from, from, nil
+	}
+
+	to, err := strconv.Atoi(last)
+	if err != nil || to < from {
+		return 0, 0, ErrParsingRange
+	}
+	return from, to, nil
+}
+
+// toGetSongsRequest parses the list filter. The presence of the cursor
+// parameter, even an empty one for the first page, switches to keyset pagination.
 func toGetSongsRequest(c *gin.Context) (*domain.SongRequest, error) {
-	offset, err := strconv.Atoi(c.Query("offset"))
+	filter, err := toSongFilter(c)
 	if err != nil {
+		return nil, err
+	}
+
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		if c.Query("offset") != "" {
+			return nil, ErrCursorWithOffset
+		}
+		if len(filter.Sort) > 0 {
+			return nil, ErrCursorWithSort
+		}
+		filter.Keyset = true
+		if cursor != "" {
+			filter.After, err = decodeCursor(cursor)
+			if err != nil {
+				return nil, ErrParsingCursor
+			}
+		}
+	} else {
+		filter.Offset, err = strconv.Atoi(c.Query("offset"))
+		if err != nil || filter.Offset < 0 {
+			return nil, ErrParsingNumber
+		}
+	}
+
+	filter.Limit, err = strconv.Atoi(c.Query("limit"))
+	if err != nil || filter.Limit <= 0 || filter.Limit > maxSongsLimit {
 		return nil, ErrParsingNumber
 	}
 
-	limit, err := strconv.Atoi(c.Query("limit"))
+	filter.Facets, err = toSongFacets(c.QueryArray("facets"))
 	if err != nil {
-		return nil, ErrParsingNumber
+		return nil, err
 	}
 
+	return filter, nil
+}
+
+// toSongFilter parses the filters of the song list shared by GetSongs and ExportSongs.
+func toSongFilter(c *gin.Context) (*domain.SongRequest, error) {
 	filter := domain.SongRequest{
-		Group:  c.Query("group"),
-		Name:   c.Query("name"),
-		Link:   c.Query("link"),
-		Offset: offset,
-		Limit:  limit,
+		Group: c.Query("group"),
+		Name:  c.Query("name"),
+		Link:  c.Query("link"),
 	}
 
+	var err error
 	if c.Query("release_date") != "" {
 		filter.ReleaseDate, err = time.Parse(time.DateOnly, c.Query("release_date"))
 		if err != nil {
@@ -105,37 +559,812 @@ func toGetSongsRequest(c *gin.Context) (*domain.SongRequest, error) {
 		}
 	}
 
+	if c.Query("release_date_from") != "" {
+		filter.ReleaseFrom, err = time.Parse(time.DateOnly, c.Query("release_date_from"))
+		if err != nil {
+			return nil, ErrParsingCreateDate
+		}
+	}
+
+	if c.Query("release_date_to") != "" {
+		filter.ReleaseTo, err = time.Parse(time.DateOnly, c.Query("release_date_to"))
+		if err != nil {
+			return nil, ErrParsingCreateDate
+		}
+		if filter.ReleaseTo.Before(filter.ReleaseFrom) {
+			return nil, ErrReleaseDateRange
+		}
+	}
+
+	if c.Query("year") != "" {
+		filter.Year, err = strconv.Atoi(c.Query("year"))
+		if err != nil || filter.Year < 1 || filter.Year > 9999 {
+			return nil, ErrParsingYear
+		}
+	}
+
+	if c.Query("created_since") != "" {
+		filter.CreatedSince, err = time.Parse(time.RFC3339, c.Query("created_since"))
+		if err != nil {
+			return nil, ErrParsingTime
+		}
+	}
+
+	if c.Query("updated_since") != "" {
+		filter.UpdatedSince, err = time.Parse(time.RFC3339, c.Query("updated_since"))
+		if err != nil {
+			return nil, ErrParsingTime
+		}
+	}
+
+	filter.Sort, err = toSongSort(c.QueryArray("sort"))
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Query("artist_id") != "" {
+		filter.ArtistID, err = uuid.Parse(c.Query("artist_id"))
+		if err != nil {
+			return nil, ErrParsingID
+		}
+	}
+
+	if c.Query("album_id") != "" {
+		filter.AlbumID, err = uuid.Parse(c.Query("album_id"))
+		if err != nil {
+			return nil, ErrParsingID
+		}
+	}
+
 	return &filter, nil
 }
 
+// songSortFields is the allow-list of the sort keys of the song list.
+var songSortFields = map[string]domain.SongSortField{
+	"name":         domain.SortName,
+	"group":        domain.SortGroup,
+	"release_date": domain.SortReleaseDate,
+	"created_at":   domain.SortCreatedAt,
+}
+
+// toSongSort parses the sort keys such as name,-release_date, the minus sign
+// sorts in descending order. A key can't be repeated.
+func toSongSort(values []string) ([]domain.SongSort, error) {
+	var sort []domain.SongSort
+	seen := make(map[domain.SongSortField]bool)
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		for _, key := range strings.Split(value, ",") {
+			key = strings.TrimSpace(key)
+			desc := strings.HasPrefix(key, "-")
+			field, ok := songSortFields[strings.TrimPrefix(key, "-")]
+			if !ok || seen[field] {
+				return nil, ErrParsingSort
+			}
+			seen[field] = true
+			sort = append(sort, domain.SongSort{Field: field, Desc: desc})
+		}
+	}
+	return sort, nil
+}
+
+// songFacets is the allow-list of the facets of the song list.
+var songFacets = map[string]domain.SongFacet{
+	"group":   domain.FacetGroup,
+	"year":    domain.FacetYear,
+	"section": domain.FacetSection,
+}
+
+// toSongFacets parses the facets such as group,year. A facet can't be repeated.
+func toSongFacets(values []string) ([]domain.SongFacet, error) {
+	var facets []domain.SongFacet
+	seen := make(map[domain.SongFacet]bool)
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+		for _, name := range strings.Split(value, ",") {
+			facet, ok := songFacets[strings.TrimSpace(name)]
+			if !ok || seen[facet] {
+				return nil, ErrParsingFacets
+			}
+			seen[facet] = true
+			facets = append(facets, facet)
+		}
+	}
+	return facets, nil
+}
+
+// toExportRequest parses the filters and the format of the export.
+func toExportRequest(c *gin.Context) (*domain.SongRequest, exportFormat, error) {
+	format := exportFormat(c.DefaultQuery("format", string(exportNDJSON)))
+	switch format {
+	case exportNDJSON, exportCSV, exportZip:
+	default:
+		return nil, "", ErrParsingFormat
+	}
+
+	filter, err := toSongFilter(c)
+	if err != nil {
+		return nil, "", err
+	}
+	return filter, format, nil
+}
+
+func toExportSong(song *domain.Song) v1.ExportSong {
+	resp := v1.ExportSong{
+		ID:          song.ID.String(),
+		Group:       song.Group,
+		Name:        song.Name,
+		Link:        song.Link,
+		ReleaseDate: song.ReleaseDate.Format(time.DateOnly),
+		Text:        make([]v1.ExportSongItem, 0, len(song.Text)),
+		Version:     song.Version,
+		CreatedAt:   song.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   song.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, item := range song.Text {
+		exportItem := v1.ExportSongItem{
+			Type:  string(item.Type),
+			Label: item.Label,
+			Text:  item.Text,
+		}
+		for _, chord := range item.Chords {
+			exportItem.Chords = append(exportItem.Chords, v1.Chord(chord))
+		}
+		for _, line := range item.Timing {
+			timedLine := v1.TimedLine{Start: line.Start}
+			for _, word := range line.Words {
+				timedLine.Words = append(timedLine.Words, v1.TimedWord(word))
+			}
+			exportItem.Timing = append(exportItem.Timing, timedLine)
+		}
+		resp.Text = append(resp.Text, exportItem)
+	}
+	return resp
+}
+
 func toGetSongsResponse(s []domain.Song) []v1.Song {
 	songs := make([]v1.Song, 0, len(s))
+	for i := range s {
+		songs = append(songs, toSongResponse(&s[i]))
+	}
+	return songs
+}
+
+func toSongResponse(value *domain.Song) v1.Song {
+	song := v1.Song{
+		Name:        value.Name,
+		Group:       value.Group,
+		ReleaseDate: value.ReleaseDate.Format(time.DateOnly),
+		Link:        value.Link,
+	}
+	if value.ID != uuid.Nil {
+		song.ID = value.ID.String()
+	}
+	if value.ArtistID != uuid.Nil {
+		song.ArtistID = value.ArtistID.String()
+	}
+	if !value.CreatedAt.IsZero() {
+		song.CreatedAt = value.CreatedAt.Format(time.RFC3339)
+	}
+	if !value.UpdatedAt.IsZero() {
+		song.UpdatedAt = value.UpdatedAt.Format(time.RFC3339)
+	}
+	song.Version = value.Version
+	for _, id := range value.FeaturedArtistIDs {
+		song.FeaturedArtistIDs = append(song.FeaturedArtistIDs, id.String())
+	}
+	for _, item := range value.Text {
+		song.Text = append(song.Text, v1.SongItem{
+			Type:  string(item.Type),
+			Label: item.Label,
+			Text:  item.Text,
+		})
+	}
+	return song
+}
+
+func toGetTrashRequest(c *gin.Context) (*domain.TrashRequest, error) {
+	filter := domain.TrashRequest{
+		Limit: defaultSearchLimit,
+	}
+
+	var err error
+	if c.Query("limit") != "" {
+		filter.Limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || filter.Limit <= 0 || filter.Limit > maxSearchLimit {
+			return nil, ErrParsingNumber
+		}
+	}
+	if c.Query("offset") != "" {
+		filter.Offset, err = strconv.Atoi(c.Query("offset"))
+		if err != nil || filter.Offset < 0 {
+			return nil, ErrParsingNumber
+		}
+	}
+
+	return &filter, nil
+}
+
+func toGetTrashResponse(s []domain.Song) []v1.DeletedSong {
+	songs := make([]v1.DeletedSong, 0, len(s))
 	for _, value := range s {
-		songs = append(songs, v1.Song{
+		songs = append(songs, v1.DeletedSong{
+			ID:          value.ID.String(),
 			Name:        value.Name,
 			Group:       value.Group,
 			ReleaseDate: value.ReleaseDate.Format(time.DateOnly),
 			Link:        value.Link,
+			DeletedAt:   value.DeletedAt.Format(time.RFC3339),
 		})
 	}
 	return songs
 }
 
+func toRevisionRequest(c *gin.Context) (*uuid.UUID, int, error) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return nil, 0, ErrParsingID
+	}
+
+	revision, err := strconv.Atoi(c.Param("rev"))
+	if err != nil || revision <= 0 {
+		return nil, 0, ErrParsingRevision
+	}
+
+	return &id, revision, nil
+}
+
+func toRevisionResponse(rev *domain.SongRevision) v1.SongRevision {
+	return v1.SongRevision{
+		Revision:  rev.Revision,
+		CreatedAt: rev.CreatedAt.Format(time.RFC3339),
+		Song:      toSongResponse(&rev.Song),
+	}
+}
+
+// toRevisionsResponse lists the revisions without lyrics,
+// they are returned for a single revision only.
+func toRevisionsResponse(r []domain.SongRevision) []v1.SongRevision {
+	revisions := make([]v1.SongRevision, 0, len(r))
+	for i := range r {
+		rev := toRevisionResponse(&r[i])
+		rev.Song.Text = nil
+		revisions = append(revisions, rev)
+	}
+	return revisions
+}
+
+func toSearchRequest(c *gin.Context) (*domain.SearchRequest, error) {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return nil, ErrQueryIsEmpty
+	}
+
+	filter := domain.SearchRequest{
+		Query: query,
+		Limit: defaultSearchLimit,
+	}
+
+	var err error
+	if c.Query("limit") != "" {
+		filter.Limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || filter.Limit <= 0 || filter.Limit > maxSearchLimit {
+			return nil, ErrParsingNumber
+		}
+	}
+	if c.Query("offset") != "" {
+		filter.Offset, err = strconv.Atoi(c.Query("offset"))
+		if err != nil || filter.Offset < 0 {
+			return nil, ErrParsingNumber
+		}
+	}
+
+	return &filter, nil
+}
+
+// toSuggestRequest parses the type-ahead query, a query longer than
+// maxSuggestQuery characters is cut.
+func toSuggestRequest(c *gin.Context) (*domain.SuggestRequest, error) {
+	query := []rune(strings.TrimSpace(c.Query("q")))
+	if len(query) == 0 {
+		return nil, ErrQueryIsEmpty
+	}
+	if len(query) > maxSuggestQuery {
+		query = query[:maxSuggestQuery]
+	}
+
+	filter := domain.SuggestRequest{
+		Query: string(query),
+		Limit: defaultSuggestLimit,
+	}
+
+	if c.Query("limit") != "" {
+		var err error
+		filter.Limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || filter.Limit <= 0 || filter.Limit > maxSuggestLimit {
+			return nil, ErrParsingNumber
+		}
+	}
+
+	return &filter, nil
+}
+
+func toSuggestResponse(suggestions []domain.Suggestion) []v1.Suggestion {
+	resp := make([]v1.Suggestion, 0, len(suggestions))
+	for _, value := range suggestions {
+		resp = append(resp, v1.Suggestion{
+			Kind:  string(value.Kind),
+			ID:    value.ID.String(),
+			Text:  value.Text,
+			Group: value.Group,
+		})
+	}
+	return resp
+}
+
+func toSearchResponse(results []domain.SearchResult) []v1.SearchResult {
+	resp := make([]v1.SearchResult, 0, len(results))
+	for _, value := range results {
+		res := v1.SearchResult{
+			ID:    value.ID.String(),
+			Name:  value.Name,
+			Group: value.Group,
+			Rank:  value.Rank,
+		}
+		if value.SectionIndex > 0 {
+			res.Section = &v1.MatchedSection{
+				Index:   value.SectionIndex,
+				Type:    string(value.SectionType),
+				Snippet: toSnippet(value.Snippet),
+			}
+		}
+		resp = append(resp, res)
+	}
+	return resp
+}
+
+// toSnippet escapes the lyrics of the snippet as HTML and wraps
+// the matched words in <mark>.
+func toSnippet(snippet string) string {
+	return strings.NewReplacer(
+		domain.SnippetStart, "<mark>",
+		domain.SnippetStop, "</mark>",
+	).Replace(html.EscapeString(snippet))
+}
+
+// toFacetsResponse has a list of counts for each requested facet,
+// the list is empty when no song has a value of the facet.
+func toFacetsResponse(requested []domain.SongFacet, facets map[domain.SongFacet][]domain.FacetCount) map[string][]v1.FacetCount {
+	resp := make(map[string][]v1.FacetCount, len(requested))
+	for _, facet := range requested {
+		counts := make([]v1.FacetCount, 0, len(facets[facet]))
+		for _, count := range facets[facet] {
+			counts = append(counts, v1.FacetCount(count))
+		}
+		resp[string(facet)] = counts
+	}
+	return resp
+}
+
+// toNextCursor returns the cursor of the next page in keyset mode,
+// it is empty when the page is not full.
+func toNextCursor(filter *domain.SongRequest, songs []domain.Song) string {
+	if !filter.Keyset || len(songs) == 0 || len(songs) < filter.Limit {
+		return ""
+	}
+	last := songs[len(songs)-1]
+	return encodeCursor(&domain.SongCursor{
+		CreatedAt: last.CreatedAt,
+		ID:        last.ID,
+	})
+}
+
+func encodeCursor(cursor *domain.SongCursor) string {
+	raw := cursor.CreatedAt.UTC().Format(time.RFC3339Nano) + cursorSeparator + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (*domain.SongCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt, id, ok := strings.Cut(string(raw), cursorSeparator)
+	if !ok {
+		return nil, ErrParsingCursor
+	}
+
+	var result domain.SongCursor
+	result.CreatedAt, err = time.Parse(time.RFC3339Nano, createdAt)
+	if err != nil {
+		return nil, err
+	}
+	result.ID, err = uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func toDomainArtist(artist v1.Artist) (*domain.Artist, error) {
+	name := strings.TrimSpace(artist.Name)
+	if name == "" {
+		return nil, ErrNameIsEmpty
+	}
+
+	return &domain.Artist{
+		Name:        name,
+		Description: artist.Description,
+	}, nil
+}
+
+// toArtistPatch keeps the fields absent from the body of a PATCH nil.
+func toArtistPatch(artist v1.ArtistPatch) (*domain.ArtistPatch, error) {
+	patch := domain.ArtistPatch{Description: artist.Description}
+	if artist.Name != nil {
+		name := strings.TrimSpace(*artist.Name)
+		if name == "" {
+			return nil, ErrNameIsEmpty
+		}
+		patch.Name = &name
+	}
+	return &patch, nil
+}
+
+func toArtistResponse(artist *domain.Artist) v1.Artist {
+	return v1.Artist{
+		ID:          artist.ID.String(),
+		Name:        artist.Name,
+		Description: artist.Description,
+		CreatedAt:   artist.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   artist.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toGetArtistsRequest(c *gin.Context) (*domain.ArtistRequest, error) {
+	filter := domain.ArtistRequest{
+		Name:  c.Query("name"),
+		Limit: defaultArtistLimit,
+	}
+
+	var err error
+	if c.Query("limit") != "" {
+		filter.Limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || filter.Limit <= 0 || filter.Limit > maxArtistLimit {
+			return nil, ErrParsingNumber
+		}
+	}
+	if c.Query("offset") != "" {
+		filter.Offset, err = strconv.Atoi(c.Query("offset"))
+		if err != nil || filter.Offset < 0 {
+			return nil, ErrParsingNumber
+		}
+	}
+
+	return &filter, nil
+}
+
+func toGetArtistsResponse(a []domain.Artist) []v1.Artist {
+	artists := make([]v1.Artist, 0, len(a))
+	for i := range a {
+		artists = append(artists, toArtistResponse(&a[i]))
+	}
+	return artists
+}
+
+func toDomainAlbum(album v1.Album) (*domain.Album, error) {
+	title := strings.TrimSpace(album.Title)
+	if title == "" {
+		return nil, ErrTitleIsEmpty
+	}
+	if album.Artist == "" && album.ArtistID == "" {
+		return nil, ErrGroupIsEmpty
+	}
+
+	var artistID uuid.UUID
+	if album.ArtistID != "" {
+		var err error
+		artistID, err = uuid.Parse(album.ArtistID)
+		if err != nil {
+			return nil, ErrParsingID
+		}
+	}
+
+	releaseDate, err := time.Parse(time.DateOnly, album.ReleaseDate)
+	if err != nil {
+		return nil, ErrParsingCreateDate
+	}
+
+	songIDs, err := toTrackIDs(album.TrackIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make([]domain.AlbumTrack, 0, len(songIDs))
+	for i, id := range songIDs {
+		tracks = append(tracks, domain.AlbumTrack{
+			Position: i + 1,
+			SongID:   id,
+		})
+	}
+
+	return &domain.Album{
+		Title:       title,
+		Artist:      album.Artist,
+		ArtistID:    artistID,
+		ReleaseDate: releaseDate,
+		Tracks:      tracks,
+	}, nil
+}
+
+// toAlbumPatch keeps the fields absent from the body of a PATCH nil,
+// the track list can't be changed by a PATCH.
+func toAlbumPatch(album v1.AlbumPatch) (*domain.AlbumPatch, error) {
+	if album.TrackIDs != nil {
+		return nil, ErrTracksInPatch
+	}
+
+	var patch domain.AlbumPatch
+	if album.Title != nil {
+		title := strings.TrimSpace(*album.Title)
+		if title == "" {
+			return nil, ErrTitleIsEmpty
+		}
+		patch.Title = &title
+	}
+	if album.ArtistID != nil {
+		artistID, err := uuid.Parse(*album.ArtistID)
+		if err != nil {
+			return nil, ErrParsingID
+		}
+		patch.ArtistID = &artistID
+	} else if album.Artist != nil {
+		artist := strings.TrimSpace(*album.Artist)
+		if artist == "" {
+			return nil, ErrGroupIsEmpty
+		}
+		patch.Artist = &artist
+	}
+	if album.ReleaseDate != nil {
+		releaseDate, err := time.Parse(time.DateOnly, *album.ReleaseDate)
+		if err != nil {
+			return nil, ErrParsingCreateDate
+		}
+		patch.ReleaseDate = &releaseDate
+	}
+	return &patch, nil
+}
+
+// toTrackIDs parses the track list, a song can appear in the album only once.
+func toTrackIDs(ids []string) ([]uuid.UUID, error) {
+	result := make([]uuid.UUID, 0, len(ids))
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	for _, val := range ids {
+		id, err := uuid.Parse(val)
+		if err != nil {
+			return nil, ErrParsingID
+		}
+		if _, ok := seen[id]; ok {
+			return nil, ErrDuplicateTrack
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result, nil
+}
+
+func toAlbumResponse(album *domain.Album) v1.Album {
+	resp := v1.Album{
+		ID:          album.ID.String(),
+		Title:       album.Title,
+		Artist:      album.Artist,
+		ArtistID:    album.ArtistID.String(),
+		ReleaseDate: album.ReleaseDate.Format(time.DateOnly),
+		CreatedAt:   album.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   album.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, t := range album.Tracks {
+		resp.Tracks = append(resp.Tracks, v1.AlbumTrack{
+			Position: t.Position,
+			SongID:   t.SongID.String(),
+			Name:     t.Name,
+		})
+	}
+	return resp
+}
+
+func toGetAlbumsRequest(c *gin.Context) (*domain.AlbumRequest, error) {
+	filter := domain.AlbumRequest{
+		Title: c.Query("title"),
+		Limit: defaultSearchLimit,
+	}
+
+	var err error
+	if c.Query("artist_id") != "" {
+		filter.ArtistID, err = uuid.Parse(c.Query("artist_id"))
+		if err != nil {
+			return nil, ErrParsingID
+		}
+	}
+	if c.Query("limit") != "" {
+		filter.Limit, err = strconv.Atoi(c.Query("limit"))
+		if err != nil || filter.Limit <= 0 || filter.Limit > maxSearchLimit {
+			return nil, ErrParsingNumber
+		}
+	}
+	if c.Query("offset") != "" {
+		filter.Offset, err = strconv.Atoi(c.Query("offset"))
+		if err != nil || filter.Offset < 0 {
+			return nil, ErrParsingNumber
+		}
+	}
+
+	return &filter, nil
+}
+
+func toGetAlbumsResponse(a []domain.Album) []v1.Album {
+	albums := make([]v1.Album, 0, len(a))
+	for i := range a {
+		albums = append(albums, toAlbumResponse(&a[i]))
+	}
+	return albums
+}
+
+// toLang returns the canonical form of a BCP 47 language tag, "EN-us" is "en-US".
+func toLang(value string) (string, error) {
+	tag, err := language.Parse(value)
+	if err != nil || tag == language.Und {
+		return "", ErrParsingLang
+	}
+	return tag.String(), nil
+}
+
+// toDomainTranslation converts the translation to the language. The types
+// of the sections may be omitted, they are taken from the original lyrics.
+func toDomainTranslation(songID uuid.UUID, lang string, t v1.Translation) (*domain.Translation, error) {
+	lang, err := toLang(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	text := make(domain.SongText, 0, len(t.Text))
+	for _, val := range t.Text {
+		if val.Text == "" {
+			return nil, ErrTextIsEmpty
+		}
+		label := strings.TrimSpace(val.Label)
+		if utf8.RuneCountInString(label) > maxLabelLength {
+			return nil, ErrLabelTooLong
+		}
+		item := domain.SongItem{
+			Type:  domain.TypeSongItem(val.Type),
+			Label: label,
+			Text:  val.Text,
+		}
+		if item.Type != "" && !item.Type.IsValid() {
+			return nil, errInvalidText
+		}
+		text = append(text, item)
+	}
+	if len(text) == 0 {
+		return nil, ErrTextIsEmpty
+	}
+
+	return &domain.Translation{
+		SongID: songID,
+		Lang:   lang,
+		Text:   text,
+	}, nil
+}
+
+func toTranslationResponse(t *domain.Translation) v1.Translation {
+	return v1.Translation{
+		Lang:      t.Lang,
+		Text:      toSongTextResponse(t.Text),
+		CreatedAt: t.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: t.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func toSongTextResponse(text domain.SongText) []v1.SongItem {
+	items := make([]v1.SongItem, 0, len(text))
+	for _, item := range text {
+		items = append(items, v1.SongItem{
+			Type:  string(item.Type),
+			Label: item.Label,
+			Text:  item.Text,
+		})
+	}
+	return items
+}
+
+func toTranslationsResponse(t []domain.Translation) []v1.Translation {
+	translations := make([]v1.Translation, 0, len(t))
+	for i := range t {
+		translations = append(translations, toTranslationResponse(&t[i]))
+	}
+	return translations
+}
+
 func errToHttpStatus(err error) int {
 	if err == nil {
 		return http.StatusOK
 	}
 
 	switch {
+	case errors.Is(err, service.ErrIdempotencyKeyMismatch):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, service.ErrIdempotencyKeyInProgress):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrVersionMismatch):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, patch.ErrTestFailed):
+		return http.StatusConflict
+	case errors.Is(err, ErrUnsupportedPatch),
+		errors.Is(err, ErrUnsupportedImport):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, ErrImportRowTooLong):
+		return http.StatusRequestEntityTooLarge
 	case errors.Is(err, ErrParsingCreateDate),
 		errors.Is(err, ErrParsingID),
 		errors.Is(err, ErrNameIsEmpty),
 		errors.Is(err, ErrGroupIsEmpty),
 		errors.Is(err, ErrLinkNotCorrect),
-		errors.Is(err, ErrTextIsEmpty):
+		errors.Is(err, ErrTextIsEmpty),
+		errors.Is(err, ErrLabelTooLong),
+		errors.Is(err, errInvalidText),
+		errors.Is(err, errInvalidRequest),
+		errors.Is(err, ErrParsingRange),
+		errors.Is(err, service.ErrSectionOutOfRange),
+		errors.Is(err, service.ErrLineOutOfRange),
+		errors.Is(err, ErrQueryIsEmpty),
+		errors.Is(err, ErrParsingNumber),
+		errors.Is(err, ErrParsingFlag),
+		errors.Is(err, lyrics.ErrUnknownFormat),
+		errors.Is(err, lyrics.ErrInvalidLRC),
+		errors.Is(err, lyrics.ErrInvalidChordPro),
+		errors.Is(err, ErrParsingTranspose),
+		errors.Is(err, ErrParsingLang),
+		errors.Is(err, domain.ErrTranslationMismatch),
+		errors.Is(err, ErrParsingCursor),
+		errors.Is(err, ErrCursorWithOffset),
+		errors.Is(err, ErrCursorWithSort),
+		errors.Is(err, ErrParsingSort),
+		errors.Is(err, ErrParsingFacets),
+		errors.Is(err, ErrParsingYear),
+		errors.Is(err, ErrParsingTime),
+		errors.Is(err, ErrReleaseDateRange),
+		errors.Is(err, ErrParsingRevision),
+		errors.Is(err, ErrParsingETag),
+		errors.Is(err, ErrIdempotencyKey),
+		errors.Is(err, service.ErrInvalidPatch),
+		errors.Is(err, ErrTitleIsEmpty),
+		errors.Is(err, ErrDuplicateTrack),
+		errors.Is(err, ErrTracksInPatch),
+		errors.Is(err, ErrParsingColumns),
+		errors.Is(err, ErrParsingFormat):
 		return http.StatusBadRequest
-	case errors.Is(err, service.ErrSongNotFound):
+	case errors.Is(err, service.ErrSongNotFound),
+		errors.Is(err, service.ErrSongInfoNotFound),
+		errors.Is(err, service.ErrArtistNotFound),
+		errors.Is(err, service.ErrAlbumNotFound),
+		errors.Is(err, service.ErrRevisionNotFound),
+		errors.Is(err, service.ErrTranslationNotFound),
+		errors.Is(err, lyrics.ErrNoTiming):
 		return http.StatusNotFound
+	case errors.Is(err, service.ErrSongExists),
+		errors.Is(err, service.ErrTranslationExists),
+		errors.Is(err, service.ErrArtistExists),
+		errors.Is(err, service.ErrArtistHasSongs):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrGetSongInfo):
+		return http.StatusBadGateway
 	default:
 		return http.StatusInternalServerError
 	}
@@ -1,12 +1,21 @@
 package api
 
 import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"testing/iotest"
 	"time"
 
 	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/lyrics"
+	"github.com/Alina9496/library/internal/patch"
+	"github.com/Alina9496/library/internal/service"
 	v1 "github.com/Alina9496/library/pkg/api/v1"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -16,6 +25,7 @@ import (
 func Test_toDomainSong(t *testing.T) {
 	dateString := "2006-01-02"
 	date, _ := time.Parse(time.DateOnly, dateString)
+	artistID, featuredID := uuid.New(), uuid.New()
 
 	tests := []struct {
 		name    string
@@ -153,6 +163,101 @@ func Test_toDomainSong(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "sections with labels",
+			song: v1.Song{
+				Group:       "group",
+				Name:        "name",
+				Link:        "https://example.org/",
+				ReleaseDate: dateString,
+				Text: []v1.SongItem{
+					{Type: "intro", Text: "intro"},
+					{Type: "verse", Label: " Verse 1 ", Text: "verse"},
+					{Type: "pre-chorus", Text: "pre-chorus"},
+					{Type: "chorus", Label: "Chorus (x2)", Text: "chorus"},
+					{Type: "refrain", Text: "refrain"},
+					{Type: "hook", Text: "hook"},
+					{Type: "bridge", Text: "bridge"},
+					{Type: "outro", Text: "outro"},
+				},
+			},
+			want: &domain.Song{
+				Group:       "group",
+				Name:        "name",
+				Link:        "https://example.org/",
+				ReleaseDate: date,
+				Text: domain.SongText{
+					{Type: domain.Intro, Text: "intro"},
+					{Type: domain.Verse, Label: "Verse 1", Text: "verse"},
+					{Type: domain.PreChorus, Text: "pre-chorus"},
+					{Type: domain.Chorus, Label: "Chorus (x2)", Text: "chorus"},
+					{Type: domain.Refrain, Text: "refrain"},
+					{Type: domain.Hook, Text: "hook"},
+					{Type: domain.Bridge, Text: "bridge"},
+					{Type: domain.Outro, Text: "outro"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "label is too long",
+			song: v1.Song{
+				Group: "group",
+				Name:  "name",
+				Text: []v1.SongItem{
+					{Type: "verse", Label: strings.Repeat("l", maxLabelLength+1), Text: "text"},
+				},
+			},
+			want:    nil,
+			wantErr: ErrLabelTooLong,
+		},
+		{
+			name: "error parsing artist id",
+			song: v1.Song{
+				Name:     "name",
+				ArtistID: "artist",
+			},
+			want:    nil,
+			wantErr: ErrParsingID,
+		},
+		{
+			name: "error parsing featured artist id",
+			song: v1.Song{
+				Name:              "name",
+				Group:             "group",
+				FeaturedArtistIDs: []string{"artist"},
+			},
+			want:    nil,
+			wantErr: ErrParsingID,
+		},
+		{
+			name: "artist by id",
+			song: v1.Song{
+				Name:              "name",
+				ArtistID:          artistID.String(),
+				FeaturedArtistIDs: []string{featuredID.String()},
+			},
+			want: &domain.Song{
+				Name:              "name",
+				ArtistID:          artistID,
+				FeaturedArtistIDs: []uuid.UUID{featuredID},
+				Text:              domain.SongText{},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "details are left for the song info provider",
+			song: v1.Song{
+				Group: "group",
+				Name:  "name",
+			},
+			want: &domain.Song{
+				Group: "group",
+				Name:  "name",
+				Text:  domain.SongText{},
+			},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -163,6 +268,49 @@ func Test_toDomainSong(t *testing.T) {
 	}
 }
 
+func Test_toDomainFullSong(t *testing.T) {
+	tests := []struct {
+		name    string
+		song    v1.Song
+		wantErr error
+	}{
+		{
+			name: "link is empty",
+			song: v1.Song{
+				Group:       "group",
+				Name:        "name",
+				ReleaseDate: "2006-01-02",
+			},
+			wantErr: ErrLinkNotCorrect,
+		},
+		{
+			name: "release date is empty",
+			song: v1.Song{
+				Group: "group",
+				Name:  "name",
+				Link:  "https://example.org/",
+			},
+			wantErr: ErrParsingCreateDate,
+		},
+		{
+			name: "full song",
+			song: v1.Song{
+				Group:       "group",
+				Name:        "name",
+				Link:        "https://example.org/",
+				ReleaseDate: "2006-01-02",
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := toDomainFullSong(tt.song)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
 func Test_toRespID(t *testing.T) {
 	id := uuid.New()
 	tests := []struct {
@@ -206,24 +354,93 @@ func Test_toGetTextSongRequest(t *testing.T) {
 			err:   errInvalidRequest,
 		},
 		{
-			name:  "offset is empty",
-			query: "/test?group=group&name=name",
+			name:  "error parsing offset",
+			query: "/test?group=group&name=name&offset=e",
+			want:  nil,
+			err:   ErrParsingNumber,
+		},
+		{
+			name:  "offset starts from one",
+			query: "/test?group=group&name=name&offset=0",
+			want:  nil,
+			err:   ErrParsingRange,
+		},
+		{
+			name:  "offset and index together",
+			query: "/test?group=group&name=name&offset=1&index=1",
 			want:  nil,
 			err:   errInvalidRequest,
 		},
 		{
-			name:  "error parsing offset",
-			query: "/test?group=group&name=name&offset=e",
+			name:  "unknown section",
+			query: "/test?group=group&name=name&section=solo",
 			want:  nil,
-			err:   ErrParsingNumber,
+			err:   errInvalidText,
 		},
 		{
-			name:  "conversion in domain.SongRequest",
+			name:  "error parsing index",
+			query: "/test?group=group&name=name&index=3-2",
+			want:  nil,
+			err:   ErrParsingRange,
+		},
+		{
+			name:  "lines of several sections",
+			query: "/test?group=group&name=name&index=1-2&lines=1",
+			want:  nil,
+			err:   ErrParsingRange,
+		},
+		{
+			name:  "offset is the index of a verse",
 			query: "/test?group=group&name=name&offset=1",
 			want: &domain.SongRequest{
-				Group:  "group",
-				Name:   "name",
-				Offset: 1,
+				Group: "group",
+				Name:  "name",
+				Text:  domain.TextAddress{Section: domain.Verse, From: 1},
+			},
+			err: nil,
+		},
+		{
+			name:  "first section by default",
+			query: "/test?group=group&name=name",
+			want: &domain.SongRequest{
+				Group: "group",
+				Name:  "name",
+			},
+			err: nil,
+		},
+		{
+			name:  "lines of a chorus",
+			query: "/test?group=group&name=name&section=chorus&index=2&lines=1-2",
+			want: &domain.SongRequest{
+				Group: "group",
+				Name:  "name",
+				Text:  domain.TextAddress{Section: domain.Chorus, From: 2, To: 2, LineFrom: 1, LineTo: 2},
+			},
+			err: nil,
+		},
+		{
+			name:  "range of sections",
+			query: "/test?group=group&name=name&index=2-4",
+			want: &domain.SongRequest{
+				Group: "group",
+				Name:  "name",
+				Text:  domain.TextAddress{From: 2, To: 4},
+			},
+			err: nil,
+		},
+		{
+			name:  "error parsing lang",
+			query: "/test?group=group&name=name&lang=russian!",
+			want:  nil,
+			err:   ErrParsingLang,
+		},
+		{
+			name:  "translation",
+			query: "/test?group=group&name=name&lang=EN-gb",
+			want: &domain.SongRequest{
+				Group: "group",
+				Name:  "name",
+				Lang:  "en-GB",
 			},
 			err: nil,
 		},
@@ -241,6 +458,11 @@ func Test_toGetTextSongRequest(t *testing.T) {
 
 func Test_toGetSongsRequest(t *testing.T) {
 	date, _ := time.Parse(time.DateOnly, "2006-01-02")
+	cursor := &domain.SongCursor{
+		CreatedAt: time.Date(2024, 11, 3, 10, 15, 30, 123456000, time.UTC),
+		ID:        uuid.New(),
+	}
+	albumID := uuid.New()
 
 	tests := []struct {
 		name    string
@@ -260,12 +482,82 @@ func Test_toGetSongsRequest(t *testing.T) {
 			want:    nil,
 			wantErr: ErrParsingNumber,
 		},
+		{
+			name:    "negative offset",
+			query:   "/test?offset=-1&limit=1",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "negative limit",
+			query:   "/test?offset=0&limit=-1",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "zero limit",
+			query:   "/test?offset=0&limit=0",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "limit above the max",
+			query:   "/test?offset=0&limit=101",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
 		{
 			name:    "error parsing date",
 			query:   "/test?group=group&name=name&link=link&release_date=2006-0102&offset=1&limit=1",
 			want:    nil,
 			wantErr: ErrParsingCreateDate,
 		},
+		{
+			name:    "cursor with offset",
+			query:   "/test?cursor=&offset=1&limit=1",
+			want:    nil,
+			wantErr: ErrCursorWithOffset,
+		},
+		{
+			name:    "error parsing cursor",
+			query:   "/test?cursor=e&limit=1",
+			want:    nil,
+			wantErr: ErrParsingCursor,
+		},
+		{
+			name:  "first page in keyset mode",
+			query: "/test?cursor=&limit=2",
+			want: &domain.SongRequest{
+				Keyset: true,
+				Limit:  2,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "next page in keyset mode",
+			query: "/test?cursor=" + encodeCursor(cursor) + "&limit=2",
+			want: &domain.SongRequest{
+				Keyset: true,
+				After:  cursor,
+				Limit:  2,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "error parsing album id",
+			query:   "/test?album_id=e&offset=0&limit=1",
+			want:    nil,
+			wantErr: ErrParsingID,
+		},
+		{
+			name:  "filter by album",
+			query: "/test?album_id=" + albumID.String() + "&offset=0&limit=10",
+			want: &domain.SongRequest{
+				AlbumID: albumID,
+				Limit:   10,
+			},
+			wantErr: nil,
+		},
 		{
 			name:  "conversion in domain.SongRequest",
 			query: "/test?group=group&name=name&link=link&release_date=2006-01-02&offset=1&limit=1",
@@ -279,6 +571,69 @@ func Test_toGetSongsRequest(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name:    "cursor with sort",
+			query:   "/test?cursor=&sort=name&limit=1",
+			want:    nil,
+			wantErr: ErrCursorWithSort,
+		},
+		{
+			name:    "error parsing sort",
+			query:   "/test?sort=text&offset=0&limit=1",
+			want:    nil,
+			wantErr: ErrParsingSort,
+		},
+		{
+			name:    "error parsing year",
+			query:   "/test?year=0&offset=0&limit=1",
+			want:    nil,
+			wantErr: ErrParsingYear,
+		},
+		{
+			name:    "error parsing updated since",
+			query:   "/test?updated_since=2024-11-03&offset=0&limit=1",
+			want:    nil,
+			wantErr: ErrParsingTime,
+		},
+		{
+			name:    "error parsing facets",
+			query:   "/test?facets=group,link&offset=0&limit=1",
+			want:    nil,
+			wantErr: ErrParsingFacets,
+		},
+		{
+			name:  "facets",
+			query: "/test?facets=year,section&facets=group&offset=0&limit=1",
+			want: &domain.SongRequest{
+				Facets: []domain.SongFacet{domain.FacetYear, domain.FacetSection, domain.FacetGroup},
+				Limit:  1,
+			},
+			wantErr: nil,
+		},
+		{
+			name:    "empty release date range",
+			query:   "/test?release_date_from=2006-01-02&release_date_to=2006-01-01&offset=0&limit=1",
+			want:    nil,
+			wantErr: ErrReleaseDateRange,
+		},
+		{
+			name: "sort and ranges",
+			query: "/test?sort=group,-release_date&release_date_from=2006-01-02&release_date_to=2006-12-31" +
+				"&year=2006&created_since=2024-11-03T10:15:30Z&updated_since=2024-11-03T13:15:30%2B03:00&offset=0&limit=10",
+			want: &domain.SongRequest{
+				Sort: []domain.SongSort{
+					{Field: domain.SortGroup},
+					{Field: domain.SortReleaseDate, Desc: true},
+				},
+				ReleaseFrom:  date,
+				ReleaseTo:    time.Date(2006, 12, 31, 0, 0, 0, 0, time.UTC),
+				Year:         2006,
+				CreatedSince: time.Date(2024, 11, 3, 10, 15, 30, 0, time.UTC),
+				UpdatedSince: time.Date(2024, 11, 3, 13, 15, 30, 0, time.FixedZone("", 3*60*60)),
+				Limit:        10,
+			},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -291,10 +646,75 @@ func Test_toGetSongsRequest(t *testing.T) {
 	}
 }
 
+func Test_toSongSort(t *testing.T) {
+	tests := []struct {
+		name    string
+		values  []string
+		want    []domain.SongSort
+		wantErr error
+	}{
+		{
+			name: "default order",
+			want: nil,
+		},
+		{
+			name:   "empty value",
+			values: []string{""},
+			want:   nil,
+		},
+		{
+			name:   "keys with directions",
+			values: []string{"-created_at, name", "group"},
+			want: []domain.SongSort{
+				{Field: domain.SortCreatedAt, Desc: true},
+				{Field: domain.SortName},
+				{Field: domain.SortGroup},
+			},
+		},
+		{
+			name:    "unknown key",
+			values:  []string{"id"},
+			wantErr: ErrParsingSort,
+		},
+		{
+			name:    "empty key",
+			values:  []string{"name,"},
+			wantErr: ErrParsingSort,
+		},
+		{
+			name:    "repeated key",
+			values:  []string{"name,-name"},
+			wantErr: ErrParsingSort,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toSongSort(tt.values)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toFacetsResponse(t *testing.T) {
+	facets := map[domain.SongFacet][]domain.FacetCount{
+		domain.FacetGroup: {{Value: "Muse", Count: 3}, {Value: "Lady Gaga", Count: 1}},
+		domain.FacetYear:  {{Value: "2009", Count: 4}},
+	}
+
+	assert.Equal(t, map[string][]v1.FacetCount{
+		"group":   {{Value: "Muse", Count: 3}, {Value: "Lady Gaga", Count: 1}},
+		"section": {},
+	}, toFacetsResponse([]domain.SongFacet{domain.FacetGroup, domain.FacetSection}, facets))
+}
+
 func Test_toGetSongsResponse(t *testing.T) {
 	dateString := "2006-01-02"
 	date, _ := time.Parse(time.DateOnly, dateString)
 
+	id := uuid.New()
+	createdAt := time.Date(2024, 11, 3, 10, 15, 30, 0, time.UTC)
+
 	tests := []struct {
 		name string
 		s    []domain.Song
@@ -319,6 +739,31 @@ func Test_toGetSongsResponse(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "id and timestamps",
+			s: []domain.Song{
+				{
+					ID:          id,
+					Group:       "group",
+					Name:        "name",
+					ReleaseDate: date,
+					Version:     2,
+					CreatedAt:   createdAt,
+					UpdatedAt:   createdAt.Add(time.Hour),
+				},
+			},
+			want: []v1.Song{
+				{
+					ID:          id.String(),
+					Group:       "group",
+					Name:        "name",
+					ReleaseDate: dateString,
+					Version:     2,
+					CreatedAt:   "2024-11-03T10:15:30Z",
+					UpdatedAt:   "2024-11-03T11:15:30Z",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -327,3 +772,1412 @@ func Test_toGetSongsResponse(t *testing.T) {
 		})
 	}
 }
+
+func Test_toSearchRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *domain.SearchRequest
+		wantErr error
+	}{
+		{
+			name:    "query is empty",
+			query:   "/test?q=%20",
+			want:    nil,
+			wantErr: ErrQueryIsEmpty,
+		},
+		{
+			name:    "error parsing limit",
+			query:   "/test?q=poker&limit=e",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "limit is too big",
+			query:   "/test?q=poker&limit=1000",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "error parsing offset",
+			query:   "/test?q=poker&offset=-1",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:  "default limit",
+			query: "/test?q=poker%20face",
+			want: &domain.SearchRequest{
+				Query: "poker face",
+				Limit: defaultSearchLimit,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "conversion in domain.SearchRequest",
+			query: "/test?q=poker&limit=5&offset=10",
+			want: &domain.SearchRequest{
+				Query:  "poker",
+				Limit:  5,
+				Offset: 10,
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, tt.query, nil)
+			got, err := toSearchRequest(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toSuggestRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *domain.SuggestRequest
+		wantErr error
+	}{
+		{
+			name:    "empty query",
+			query:   "/test?q=%20",
+			want:    nil,
+			wantErr: ErrQueryIsEmpty,
+		},
+		{
+			name:    "error parsing limit",
+			query:   "/test?q=que&limit=51",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:  "default limit",
+			query: "/test?q=%20que",
+			want: &domain.SuggestRequest{
+				Query: "que",
+				Limit: defaultSuggestLimit,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "long query",
+			query: "/test?q=" + strings.Repeat("é", maxSuggestQuery+1) + "&limit=5",
+			want: &domain.SuggestRequest{
+				Query: strings.Repeat("é", maxSuggestQuery),
+				Limit: 5,
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, tt.query, nil)
+			got, err := toSuggestRequest(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toSuggestResponse(t *testing.T) {
+	groupID, songID := uuid.New(), uuid.New()
+	assert.Equal(t, []v1.Suggestion{
+		{Kind: "group", ID: groupID.String(), Text: "Queen"},
+		{Kind: "song", ID: songID.String(), Text: "Bohemian Rhapsody", Group: "Queen"},
+	}, toSuggestResponse([]domain.Suggestion{
+		{Kind: domain.SuggestGroup, ID: groupID, Text: "Queen"},
+		{Kind: domain.SuggestSong, ID: songID, Text: "Bohemian Rhapsody", Group: "Queen"},
+	}))
+}
+
+func Test_toSearchResponse(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		results []domain.SearchResult
+		want    []v1.SearchResult
+	}{
+		{
+			name: "conversion from domain.SearchResult in v1.SearchResult",
+			results: []domain.SearchResult{
+				{
+					ID:           id,
+					Name:         "Poker Face",
+					Group:        "Lady Gaga",
+					Rank:         0.5,
+					SectionIndex: 5,
+					SectionType:  domain.Chorus,
+					Snippet:      "No, he can't read my " + domain.SnippetStart + "poker" + domain.SnippetStop + " <b>face</b>",
+				},
+				{
+					ID:    id,
+					Name:  "Poker Face",
+					Group: "Lady Gaga",
+					Rank:  0.1,
+				},
+			},
+			want: []v1.SearchResult{
+				{
+					ID:    id.String(),
+					Name:  "Poker Face",
+					Group: "Lady Gaga",
+					Rank:  0.5,
+					Section: &v1.MatchedSection{
+						Index:   5,
+						Type:    "chorus",
+						Snippet: "No, he can&#39;t read my <mark>poker</mark> &lt;b&gt;face&lt;/b&gt;",
+					},
+				},
+				{
+					ID:    id.String(),
+					Name:  "Poker Face",
+					Group: "Lady Gaga",
+					Rank:  0.1,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toSearchResponse(tt.results)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_toNextCursor(t *testing.T) {
+	first := domain.Song{ID: uuid.New(), CreatedAt: time.Date(2024, 11, 3, 10, 0, 0, 0, time.UTC)}
+	last := domain.Song{ID: uuid.New(), CreatedAt: time.Date(2024, 11, 3, 11, 0, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name   string
+		filter *domain.SongRequest
+		songs  []domain.Song
+		want   *domain.SongCursor
+	}{
+		{
+			name:   "offset mode",
+			filter: &domain.SongRequest{Limit: 2},
+			songs:  []domain.Song{first, last},
+			want:   nil,
+		},
+		{
+			name:   "last page",
+			filter: &domain.SongRequest{Keyset: true, Limit: 3},
+			songs:  []domain.Song{first, last},
+			want:   nil,
+		},
+		{
+			name:   "full page",
+			filter: &domain.SongRequest{Keyset: true, Limit: 2},
+			songs:  []domain.Song{first, last},
+			want:   &domain.SongCursor{CreatedAt: last.CreatedAt, ID: last.ID},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := toNextCursor(tt.filter, tt.songs)
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			cursor, err := decodeCursor(got)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, cursor)
+		})
+	}
+}
+
+func Test_toDomainArtist(t *testing.T) {
+	tests := []struct {
+		name    string
+		artist  v1.Artist
+		want    *domain.Artist
+		wantErr error
+	}{
+		{
+			name:    "name is empty",
+			artist:  v1.Artist{Name: "  "},
+			want:    nil,
+			wantErr: ErrNameIsEmpty,
+		},
+		{
+			name:   "conversion from v1 in domain",
+			artist: v1.Artist{Name: " Lady Gaga ", Description: "singer"},
+			want: &domain.Artist{
+				Name:        "Lady Gaga",
+				Description: "singer",
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toDomainArtist(tt.artist)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toArtistPatch(t *testing.T) {
+	name, blank, description := " Lady Gaga ", " ", "singer"
+	trimmed := "Lady Gaga"
+
+	tests := []struct {
+		name    string
+		artist  v1.ArtistPatch
+		want    *domain.ArtistPatch
+		wantErr error
+	}{
+		{
+			name:    "name is empty",
+			artist:  v1.ArtistPatch{Name: &blank},
+			want:    nil,
+			wantErr: ErrNameIsEmpty,
+		},
+		{
+			name:    "only description",
+			artist:  v1.ArtistPatch{Description: &description},
+			want:    &domain.ArtistPatch{Description: &description},
+			wantErr: nil,
+		},
+		{
+			name:    "only name",
+			artist:  v1.ArtistPatch{Name: &name},
+			want:    &domain.ArtistPatch{Name: &trimmed},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toArtistPatch(tt.artist)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toGetArtistsRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *domain.ArtistRequest
+		wantErr error
+	}{
+		{
+			name:    "error parsing limit",
+			query:   "/test?limit=0",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "error parsing offset",
+			query:   "/test?offset=e",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:  "default limit",
+			query: "/test",
+			want: &domain.ArtistRequest{
+				Limit: defaultArtistLimit,
+			},
+			wantErr: nil,
+		},
+		{
+			name:  "conversion in domain.ArtistRequest",
+			query: "/test?name=gaga&limit=5&offset=5",
+			want: &domain.ArtistRequest{
+				Name:   "gaga",
+				Limit:  5,
+				Offset: 5,
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, tt.query, nil)
+			got, err := toGetArtistsRequest(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toAlbumPatch(t *testing.T) {
+	date, _ := time.Parse(time.DateOnly, "2006-07-03")
+	artistID := uuid.New()
+	title, blank, artist := " Absolution ", " ", "Muse"
+	trimmed, releaseDate, id, wrong := "Absolution", "2006-07-03", artistID.String(), "e"
+
+	tests := []struct {
+		name    string
+		album   v1.AlbumPatch
+		want    *domain.AlbumPatch
+		wantErr error
+	}{
+		{
+			name:    "track list",
+			album:   v1.AlbumPatch{TrackIDs: []string{}},
+			want:    nil,
+			wantErr: ErrTracksInPatch,
+		},
+		{
+			name:    "title is empty",
+			album:   v1.AlbumPatch{Title: &blank},
+			want:    nil,
+			wantErr: ErrTitleIsEmpty,
+		},
+		{
+			name:    "artist is empty",
+			album:   v1.AlbumPatch{Artist: &blank},
+			want:    nil,
+			wantErr: ErrGroupIsEmpty,
+		},
+		{
+			name:    "error parsing artist id",
+			album:   v1.AlbumPatch{ArtistID: &wrong},
+			want:    nil,
+			wantErr: ErrParsingID,
+		},
+		{
+			name:    "error parsing date",
+			album:   v1.AlbumPatch{ReleaseDate: &wrong},
+			want:    nil,
+			wantErr: ErrParsingCreateDate,
+		},
+		{
+			name:    "only title",
+			album:   v1.AlbumPatch{Title: &title},
+			want:    &domain.AlbumPatch{Title: &trimmed},
+			wantErr: nil,
+		},
+		{
+			name:    "artist id wins over the name",
+			album:   v1.AlbumPatch{Artist: &artist, ArtistID: &id, ReleaseDate: &releaseDate},
+			want:    &domain.AlbumPatch{ArtistID: &artistID, ReleaseDate: &date},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toAlbumPatch(tt.album)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toDomainAlbum(t *testing.T) {
+	date, _ := time.Parse(time.DateOnly, "2006-07-03")
+	artistID := uuid.New()
+	songID := uuid.New()
+
+	tests := []struct {
+		name    string
+		album   v1.Album
+		want    *domain.Album
+		wantErr error
+	}{
+		{
+			name:    "title is empty",
+			album:   v1.Album{Title: " ", Artist: "Muse", ReleaseDate: "2006-07-03"},
+			want:    nil,
+			wantErr: ErrTitleIsEmpty,
+		},
+		{
+			name:    "artist is empty",
+			album:   v1.Album{Title: "Black Holes and Revelations", ReleaseDate: "2006-07-03"},
+			want:    nil,
+			wantErr: ErrGroupIsEmpty,
+		},
+		{
+			name:    "error parsing date",
+			album:   v1.Album{Title: "Black Holes and Revelations", Artist: "Muse", ReleaseDate: "03.07.2006"},
+			want:    nil,
+			wantErr: ErrParsingCreateDate,
+		},
+		{
+			name: "error parsing track id",
+			album: v1.Album{
+				Title:       "Black Holes and Revelations",
+				Artist:      "Muse",
+				ReleaseDate: "2006-07-03",
+				TrackIDs:    []string{"e"},
+			},
+			want:    nil,
+			wantErr: ErrParsingID,
+		},
+		{
+			name: "repeated track",
+			album: v1.Album{
+				Title:       "Black Holes and Revelations",
+				Artist:      "Muse",
+				ReleaseDate: "2006-07-03",
+				TrackIDs:    []string{songID.String(), songID.String()},
+			},
+			want:    nil,
+			wantErr: ErrDuplicateTrack,
+		},
+		{
+			name: "conversion from v1 in domain",
+			album: v1.Album{
+				Title:       "Black Holes and Revelations",
+				ArtistID:    artistID.String(),
+				ReleaseDate: "2006-07-03",
+				TrackIDs:    []string{songID.String()},
+			},
+			want: &domain.Album{
+				Title:       "Black Holes and Revelations",
+				ArtistID:    artistID,
+				ReleaseDate: date,
+				Tracks:      []domain.AlbumTrack{{Position: 1, SongID: songID}},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toDomainAlbum(tt.album)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toGetAlbumsRequest(t *testing.T) {
+	artistID := uuid.New()
+
+	tests := []struct {
+		name    string
+		query   string
+		want    *domain.AlbumRequest
+		wantErr error
+	}{
+		{
+			name:    "error parsing artist id",
+			query:   "/test?artist_id=e",
+			want:    nil,
+			wantErr: ErrParsingID,
+		},
+		{
+			name:    "error parsing limit",
+			query:   "/test?limit=101",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:  "conversion in domain.AlbumRequest",
+			query: "/test?title=holes&artist_id=" + artistID.String() + "&limit=5&offset=5",
+			want: &domain.AlbumRequest{
+				Title:    "holes",
+				ArtistID: artistID,
+				Limit:    5,
+				Offset:   5,
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, tt.query, nil)
+			got, err := toGetAlbumsRequest(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toRevisionRequest(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name         string
+		id           string
+		rev          string
+		wantID       *uuid.UUID
+		wantRevision int
+		wantErr      error
+	}{
+		{
+			name:    "error parsing id",
+			id:      "e",
+			rev:     "1",
+			wantErr: ErrParsingID,
+		},
+		{
+			name:    "error parsing revision",
+			id:      id.String(),
+			rev:     "e",
+			wantErr: ErrParsingRevision,
+		},
+		{
+			name:    "revision starts from one",
+			id:      id.String(),
+			rev:     "0",
+			wantErr: ErrParsingRevision,
+		},
+		{
+			name:         "conversion of the path",
+			id:           id.String(),
+			rev:          "3",
+			wantID:       &id,
+			wantRevision: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Params = gin.Params{{Key: "id", Value: tt.id}, {Key: "rev", Value: tt.rev}}
+			gotID, gotRevision, err := toRevisionRequest(c)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.Equal(t, tt.wantRevision, gotRevision)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toRevisionsResponse(t *testing.T) {
+	id := uuid.New()
+	createdAt := time.Date(2024, 11, 3, 10, 15, 30, 0, time.UTC)
+	date, _ := time.Parse(time.DateOnly, "2008-09-23")
+	song := domain.Song{
+		ID:          id,
+		Name:        "Poker Face",
+		Group:       "Lady Gaga",
+		Link:        "link",
+		ReleaseDate: date,
+		Text:        domain.SongText{{Type: domain.Verse, Text: "text"}},
+	}
+	revisions := []domain.SongRevision{{Revision: 1, CreatedAt: createdAt, Song: song}}
+
+	assert.Equal(t, []v1.SongRevision{
+		{
+			Revision:  1,
+			CreatedAt: "2024-11-03T10:15:30Z",
+			Song: v1.Song{
+				ID:          id.String(),
+				Name:        "Poker Face",
+				Group:       "Lady Gaga",
+				Link:        "link",
+				ReleaseDate: "2008-09-23",
+			},
+		},
+	}, toRevisionsResponse(revisions))
+
+	assert.Equal(t, []v1.SongItem{{Type: "verse", Text: "text"}}, toRevisionResponse(&revisions[0]).Song.Text)
+}
+
+func Test_toGetTrashRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		want    *domain.TrashRequest
+		wantErr error
+	}{
+		{
+			name:    "error parsing limit",
+			query:   "/test?limit=e",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "error parsing offset",
+			query:   "/test?offset=-1",
+			want:    nil,
+			wantErr: ErrParsingNumber,
+		},
+		{
+			name:    "default limit",
+			query:   "/test",
+			want:    &domain.TrashRequest{Limit: defaultSearchLimit},
+			wantErr: nil,
+		},
+		{
+			name:    "conversion in domain.TrashRequest",
+			query:   "/test?limit=5&offset=10",
+			want:    &domain.TrashRequest{Limit: 5, Offset: 10},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, tt.query, nil)
+			got, err := toGetTrashRequest(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toPatchedSong(t *testing.T) {
+	date, _ := time.Parse(time.DateOnly, "2008-09-23")
+	artistID := uuid.New()
+	newSong := func() *domain.Song {
+		return &domain.Song{
+			ID:          uuid.New(),
+			ArtistID:    artistID,
+			Group:       "Lady Gaga",
+			Name:        "Poker Face",
+			Link:        "https://lyrsense.com/lady_gaga/poker_face",
+			ReleaseDate: date,
+			Text: domain.SongText{
+				{Type: domain.Verse, Text: "one"},
+				{Type: domain.Chorus, Text: "two"},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        func(song *domain.Song)
+		wantErr     error
+	}{
+		{
+			name:        "merge patch changes only given fields",
+			contentType: "application/merge-patch+json",
+			body:        `{"name":"Alejandro"}`,
+			want: func(song *domain.Song) {
+				song.Name = "Alejandro"
+			},
+		},
+		{
+			name:        "plain json is a merge patch",
+			contentType: "application/json",
+			body:        `{"release_date":"2009-11-23"}`,
+			want: func(song *domain.Song) {
+				song.ReleaseDate, _ = time.Parse(time.DateOnly, "2009-11-23")
+			},
+		},
+		{
+			name:        "json patch changes a section of the text",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"replace","path":"/text/1/text","value":"three"}]`,
+			want: func(song *domain.Song) {
+				song.Text[1].Text = "three"
+			},
+		},
+		{
+			name:        "removing a required field",
+			contentType: "application/merge-patch+json",
+			body:        `{"link":null}`,
+			wantErr:     ErrLinkNotCorrect,
+		},
+		{
+			name:        "unknown field",
+			contentType: "application/merge-patch+json",
+			body:        `{"title":"Alejandro"}`,
+			wantErr:     patch.ErrInvalidPatch,
+		},
+		{
+			name:        "failed test operation",
+			contentType: "application/json-patch+json",
+			body:        `[{"op":"test","path":"/name","value":"Alejandro"}]`,
+			wantErr:     patch.ErrTestFailed,
+		},
+		{
+			name:        "unsupported content type",
+			contentType: "text/plain",
+			body:        `name=Alejandro`,
+			wantErr:     ErrUnsupportedPatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			song := newSong()
+			apply, err := toPatchFunc(tt.contentType)
+			if err == nil {
+				err = toPatchedSong(song, []byte(tt.body), apply)
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr != nil {
+				return
+			}
+
+			want := newSong()
+			want.ID = song.ID
+			tt.want(want)
+			assert.Equal(t, want, song)
+		})
+	}
+}
+
+func Test_toIfMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    int
+		wantErr error
+	}{
+		{
+			name:   "no header",
+			header: "",
+			want:   0,
+		},
+		{
+			name:   "any version",
+			header: "*",
+			want:   0,
+		},
+		{
+			name:   "strong entity tag",
+			header: `"3"`,
+			want:   3,
+		},
+		{
+			name:    "weak entity tag",
+			header:  `W/"3"`,
+			wantErr: ErrParsingETag,
+		},
+		{
+			name:    "unquoted version",
+			header:  "3",
+			wantErr: ErrParsingETag,
+		},
+		{
+			name:    "version starts from one",
+			header:  `"0"`,
+			wantErr: ErrParsingETag,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toIfMatch(tt.header)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toIdempotencyKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantErr error
+	}{
+		{
+			name:   "no header",
+			header: "",
+			want:   "",
+		},
+		{
+			name:   "key is trimmed",
+			header: " 5f0c2a ",
+			want:   "5f0c2a",
+		},
+		{
+			name:    "key is too long",
+			header:  strings.Repeat("k", maxIdempotencyKeyLength+1),
+			wantErr: ErrIdempotencyKey,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toIdempotencyKey(tt.header)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toRequestHash(t *testing.T) {
+	hash := func(contentType, query, body string) string {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request, _ = http.NewRequest(http.MethodPost, "/song"+query, nil)
+		c.Request.Header.Set("Content-Type", contentType)
+		return toRequestHash(c, []byte(body))
+	}
+
+	body := `{"group":"Muse","name":"Uprising"}`
+	assert.Equal(t, hash(gin.MIMEJSON, "", body), hash(gin.MIMEJSON, "?group=Abba", body))
+	assert.NotEqual(t, hash(gin.MIMEJSON, "", body), hash(gin.MIMEJSON, "", `{"group":"Muse","name":"Starlight"}`))
+	assert.NotEqual(t, hash(gin.MIMEJSON, "", body), hash(contentTypePlainText, "", body))
+
+	lyrics := "They will not force us"
+	assert.Equal(t, hash(contentTypePlainText, "?group=Muse&name=Uprising", lyrics),
+		hash("text/plain; charset=utf-8", "?name=Uprising&group=Muse", lyrics))
+	assert.NotEqual(t, hash(contentTypePlainText, "?group=Muse&name=Uprising", lyrics),
+		hash(contentTypePlainText, "?group=Muse&name=Starlight", lyrics))
+	assert.NotEqual(t, hash(contentTypePlainText, "?group=Muse&name=Uprising", lyrics),
+		hash(contentTypePlainText, "?group=MuseUprising", lyrics))
+}
+
+func Test_toLyricsRequest(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name     string
+		id       string
+		query    string
+		wantID   *uuid.UUID
+		wantOpts lyrics.Options
+		wantErr  error
+	}{
+		{
+			name:     "error parsing id",
+			id:       "e",
+			wantOpts: lyrics.Options{Headers: true},
+			wantErr:  ErrParsingID,
+		},
+		{
+			name:     "unknown format",
+			id:       id.String(),
+			query:    "format=pdf",
+			wantOpts: lyrics.Options{Headers: true},
+			wantErr:  lyrics.ErrUnknownFormat,
+		},
+		{
+			name:     "error parsing flag",
+			id:       id.String(),
+			query:    "collapse=maybe",
+			wantOpts: lyrics.Options{Format: lyrics.Plain, Headers: true},
+			wantErr:  ErrParsingFlag,
+		},
+		{
+			name:     "defaults",
+			id:       id.String(),
+			wantID:   &id,
+			wantOpts: lyrics.Options{Format: lyrics.Plain, Headers: true},
+		},
+		{
+			name:     "all options",
+			id:       id.String(),
+			query:    "format=html&headers=false&collapse=true",
+			wantID:   &id,
+			wantOpts: lyrics.Options{Format: lyrics.HTML, Collapse: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.id}}
+			gotID, gotOpts, err := toLyricsRequest(c)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.Equal(t, tt.wantOpts, gotOpts)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toTranspose(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr error
+	}{
+		{
+			name:  "no transpose",
+			value: "",
+			want:  0,
+		},
+		{
+			name:  "down",
+			value: "-3",
+			want:  -3,
+		},
+		{
+			name:    "more than an octave",
+			value:   "12",
+			wantErr: ErrParsingTranspose,
+		},
+		{
+			name:    "not a number",
+			value:   "up",
+			wantErr: ErrParsingTranspose,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toTranspose(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toChordProSong(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    *domain.Song
+		wantErr error
+	}{
+		{
+			name:    "title is required",
+			body:    "{artist: Muse}\n[Em]Paranoia is in bloom",
+			wantErr: ErrNameIsEmpty,
+		},
+		{
+			name:    "artist is required",
+			body:    "{title: Uprising}\n[Em]Paranoia is in bloom",
+			wantErr: ErrGroupIsEmpty,
+		},
+		{
+			name:    "label is too long",
+			body:    "{title: Uprising}\n{artist: Muse}\n{start_of_verse: " + strings.Repeat("a", maxLabelLength+1) + "}\n[Em]Paranoia is in bloom\n{end_of_verse}",
+			wantErr: ErrLabelTooLong,
+		},
+		{
+			name: "song",
+			body: "{title: Uprising}\n{artist: Muse}\n[Em]Paranoia is in bloom",
+			want: &domain.Song{
+				Name:  "Uprising",
+				Group: "Muse",
+				Text: domain.SongText{{
+					Type:   domain.Verse,
+					Text:   "Paranoia is in bloom",
+					Chords: []domain.Chord{{Line: 0, Pos: 0, Name: "Em"}},
+				}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toChordProSong([]byte(tt.body))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+
+	_, err := toChordProSong([]byte("{soc}\nParanoia is in bloom"))
+	assert.ErrorIs(t, err, lyrics.ErrInvalidChordPro)
+}
+
+func Test_toPlainTextSong(t *testing.T) {
+	tests := []struct {
+		name    string
+		group   string
+		song    string
+		body    string
+		want    *domain.Song
+		wantErr error
+	}{
+		{
+			name:    "name is required",
+			group:   "Muse",
+			body:    "Paranoia is in bloom",
+			wantErr: ErrNameIsEmpty,
+		},
+		{
+			name:    "group is required",
+			song:    "Uprising",
+			body:    "Paranoia is in bloom",
+			wantErr: ErrGroupIsEmpty,
+		},
+		{
+			name:    "empty text",
+			group:   "Muse",
+			song:    "Uprising",
+			body:    "\n \n",
+			wantErr: ErrTextIsEmpty,
+		},
+		{
+			name:  "song",
+			group: "Muse",
+			song:  "Uprising",
+			body:  "Paranoia is in bloom\n\nThey will not force us\n\nParanoia is in bloom",
+			want: &domain.Song{
+				Name:  "Uprising",
+				Group: "Muse",
+				Text: domain.SongText{
+					{Type: domain.Chorus, Text: "Paranoia is in bloom"},
+					{Type: domain.Verse, Text: "They will not force us"},
+					{Type: domain.Chorus, Text: "Paranoia is in bloom"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toPlainTextSong(tt.group, tt.song, []byte(tt.body))
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toDomainTranslation(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		name    string
+		lang    string
+		text    []v1.SongItem
+		want    *domain.Translation
+		wantErr error
+	}{
+		{
+			name:    "error parsing lang",
+			lang:    "",
+			text:    []v1.SongItem{{Text: "Паранойя цветёт"}},
+			wantErr: ErrParsingLang,
+		},
+		{
+			name:    "text is empty",
+			lang:    "ru",
+			text:    []v1.SongItem{},
+			wantErr: ErrTextIsEmpty,
+		},
+		{
+			name:    "section text is empty",
+			lang:    "ru",
+			text:    []v1.SongItem{{Type: "verse"}},
+			wantErr: ErrTextIsEmpty,
+		},
+		{
+			name:    "unknown section type",
+			lang:    "ru",
+			text:    []v1.SongItem{{Type: "solo", Text: "Паранойя цветёт"}},
+			wantErr: errInvalidText,
+		},
+		{
+			name: "types may be omitted",
+			lang: "sr-latn-rs",
+			text: []v1.SongItem{{Text: "Paranoja cveta"}, {Type: "chorus", Label: " Refren ", Text: "Oni neće nas pobediti"}},
+			want: &domain.Translation{
+				SongID: id,
+				Lang:   "sr-Latn-RS",
+				Text: domain.SongText{
+					{Text: "Paranoja cveta"},
+					{Type: domain.Chorus, Label: "Refren", Text: "Oni neće nas pobediti"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toDomainTranslation(id, tt.lang, v1.Translation{Text: tt.text})
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_toImportColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string]string
+		wantErr error
+	}{
+		{
+			name:  "empty",
+			value: "",
+			want:  map[string]string{},
+		},
+		{
+			name:  "columns",
+			value: "group:Artist, name : Title,text:Lyrics",
+			want:  map[string]string{"group": "Artist", "name": "Title", "text": "Lyrics"},
+		},
+		{
+			name:    "unknown field",
+			value:   "title:Title",
+			wantErr: ErrParsingColumns,
+		},
+		{
+			name:    "no column",
+			value:   "name",
+			wantErr: ErrParsingColumns,
+		},
+		{
+			name:    "repeated field",
+			value:   "name:Title,name:Song",
+			wantErr: ErrParsingColumns,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := toImportColumns(tt.value)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_newSongSource(t *testing.T) {
+	featuredID, otherID := uuid.New(), uuid.New()
+
+	tests := []struct {
+		name        string
+		contentType string
+		columns     string
+		body        string
+		readErr     error
+		want        []importRecord
+		wantErr     error
+		wantReadErr error
+		wantRow     int
+	}{
+		{
+			name:        "unsupported content type",
+			contentType: "application/json",
+			wantErr:     ErrUnsupportedImport,
+		},
+		{
+			name:        "ndjson",
+			contentType: "application/x-ndjson",
+			body: `{"group":"Muse","name":"Uprising","text":[{"type":"verse","text":"Paranoia is in bloom"}]}` + "\n\n" +
+				`{"group":"Muse",` + "\n",
+			want: []importRecord{
+				{row: 1, song: v1.Song{
+					Group: "Muse",
+					Name:  "Uprising",
+					Text:  []v1.SongItem{{Type: "verse", Text: "Paranoia is in bloom"}},
+				}},
+				{row: 3, err: ErrParsingJSON},
+			},
+		},
+		{
+			name:        "csv with columns",
+			contentType: "text/csv",
+			columns:     "group:Artist,name:Title,text:Lyrics",
+			body: "\uFEFFArtist,Title,Lyrics,featured_artist_ids,release_date\n" +
+				"Muse,Uprising,\"Paranoia is in bloom\n\nThey will not force us\",\"" + featuredID.String() + "; " + otherID.String() + "\",2009-09-07\n" +
+				"Muse,\"Resist\"ance\n",
+			want: []importRecord{
+				{row: 2, song: v1.Song{
+					Group:             "Muse",
+					Name:              "Uprising",
+					ReleaseDate:       "2009-09-07",
+					FeaturedArtistIDs: []string{featuredID.String(), otherID.String()},
+					Text: []v1.SongItem{
+						{Type: "verse", Text: "Paranoia is in bloom"},
+						{Type: "verse", Text: "They will not force us"},
+					},
+				}},
+				{row: 5, err: ErrParsingCSV},
+			},
+		},
+		{
+			name:        "ndjson line too long",
+			contentType: "application/x-ndjson",
+			body:        "{\"name\":\"Resistance\"}\n\n" + strings.Repeat(" ", maxUploadSize+1),
+			want:        []importRecord{{row: 1, song: v1.Song{Name: "Resistance"}}},
+			wantReadErr: ErrImportRowTooLong,
+			wantRow:     3,
+		},
+		{
+			name:        "csv read error",
+			contentType: "text/csv",
+			body:        "group,name,text\nMuse,Uprising,\"Paranoia is in bloom\n\nThey will not force us\"\n",
+			readErr:     errors.ErrUnsupported,
+			want: []importRecord{
+				{row: 2, song: v1.Song{
+					Group:             "Muse",
+					Name:              "Uprising",
+					FeaturedArtistIDs: []string{},
+					Text: []v1.SongItem{
+						{Type: "verse", Text: "Paranoia is in bloom"},
+						{Type: "verse", Text: "They will not force us"},
+					},
+				}},
+			},
+			wantReadErr: errors.ErrUnsupported,
+			wantRow:     5,
+		},
+		{
+			name:        "csv without the mapped column",
+			contentType: "text/csv",
+			columns:     "name:Title",
+			body:        "group,name\nMuse,Uprising\n",
+			wantErr:     ErrParsingColumns,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := io.Reader(strings.NewReader(tt.body))
+			if tt.readErr != nil {
+				body = io.MultiReader(body, iotest.ErrReader(tt.readErr))
+			}
+			source, err := newSongSource(tt.contentType, tt.columns, body)
+			assert.Equal(t, tt.wantErr, err)
+			if err != nil {
+				return
+			}
+
+			var got []importRecord
+			for {
+				record, err := source.Next()
+				if err != nil && tt.wantReadErr != nil {
+					assert.ErrorIs(t, err, tt.wantReadErr)
+					assert.Equal(t, tt.wantRow, record.row)
+					break
+				}
+				if err != nil {
+					assert.ErrorIs(t, err, io.EOF)
+					break
+				}
+				got = append(got, record)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_addImportRow(t *testing.T) {
+	id := uuid.New()
+	report := v1.ImportReport{}
+	addImportRow(&report, 2, domain.ImportResult{Status: domain.ImportCreated, ID: id})
+	addImportRow(&report, 3, domain.ImportResult{Status: domain.ImportDuplicate, Err: service.ErrSongExists})
+	addImportRow(&report, 4, domain.ImportResult{Status: domain.ImportInvalid, Err: ErrNameIsEmpty})
+	addImportRow(&report, 5, domain.ImportResult{Status: domain.ImportFailed, Err: ErrImportRowTooLong})
+
+	assert.Equal(t, v1.ImportReport{
+		Created:   1,
+		Duplicate: 1,
+		Invalid:   1,
+		Failed:    1,
+		Rows: []v1.ImportRow{
+			{Row: 2, Status: "created", ID: id.String()},
+			{Row: 3, Status: "duplicate", Error: "song already exists"},
+			{Row: 4, Status: "invalid", Error: "Name is empty"},
+			{Row: 5, Status: "failed", Error: ErrImportRowTooLong.Error()},
+		},
+	}, report)
+}
+
+func Test_toExportRequest(t *testing.T) {
+	artistID := uuid.New()
+
+	tests := []struct {
+		name       string
+		query      string
+		want       *domain.SongRequest
+		wantFormat exportFormat
+		wantErr    error
+	}{
+		{
+			name:       "ndjson by default",
+			query:      "/test",
+			want:       &domain.SongRequest{},
+			wantFormat: exportNDJSON,
+		},
+		{
+			name:       "filters",
+			query:      "/test?format=zip&group=Muse&artist_id=" + artistID.String(),
+			want:       &domain.SongRequest{Group: "Muse", ArtistID: artistID},
+			wantFormat: exportZip,
+		},
+		{
+			name:    "unknown format",
+			query:   "/test?format=xml",
+			wantErr: ErrParsingFormat,
+		},
+		{
+			name:    "error parsing date",
+			query:   "/test?format=csv&release_date=2006-0102",
+			wantErr: ErrParsingCreateDate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request, _ = http.NewRequest(http.MethodGet, tt.query, nil)
+			got, format, err := toExportRequest(c)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFormat, format)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func Test_newExportWriter(t *testing.T) {
+	id := uuid.New()
+	updatedAt := time.Date(2024, 11, 3, 10, 15, 30, 0, time.UTC)
+	song := &domain.Song{
+		ID:          id,
+		Group:       "Muse",
+		Name:        "Uprising",
+		Link:        "https://example.com",
+		ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+		Version:     2,
+		CreatedAt:   updatedAt,
+		UpdatedAt:   updatedAt,
+		Text: domain.SongText{
+			{
+				Type:   domain.Verse,
+				Text:   "Paranoia is in bloom",
+				Chords: []domain.Chord{{Line: 0, Pos: 0, Name: "Em"}},
+				Timing: []domain.TimedLine{{Start: 1500, Words: []domain.TimedWord{{Start: 1500, Text: "Paranoia"}}}},
+			},
+			{Type: domain.Chorus, Label: "Chorus x2", Text: "They will not force us"},
+		},
+	}
+	exported := `{"id":"` + id.String() + `","group":"Muse","name":"Uprising","link":"https://example.com",` +
+		`"release_date":"2009-09-07","text":[` +
+		`{"type":"verse","text":"Paranoia is in bloom","chords":[{"line":0,"pos":0,"name":"Em"}],` +
+		`"timing":[{"start":1500,"words":[{"start":1500,"text":"Paranoia"}]}]},` +
+		`{"type":"chorus","label":"Chorus x2","text":"They will not force us"}],` +
+		`"version":2,"created_at":"2024-11-03T10:15:30Z","updated_at":"2024-11-03T10:15:30Z"}`
+
+	t.Run("ndjson", func(t *testing.T) {
+		var b strings.Builder
+		w := newExportWriter(exportNDJSON, &b)
+		assert.NoError(t, w.Write(song))
+		assert.NoError(t, w.Write(song))
+		assert.NoError(t, w.Close())
+		assert.Equal(t, exported+"\n"+exported+"\n", b.String())
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		var b strings.Builder
+		w := newExportWriter(exportCSV, &b)
+		assert.NoError(t, w.Write(song))
+		assert.NoError(t, w.Close())
+		assert.Equal(t, "id,group,name,link,release_date,text,version,created_at,updated_at\n"+
+			id.String()+`,Muse,Uprising,https://example.com,2009-09-07,"[Verse 1]`+"\n"+
+			"Paranoia is in bloom\n\n[Chorus x2]\nThey will not force us\n"+
+			`",2,2024-11-03T10:15:30Z,2024-11-03T10:15:30Z`+"\n", b.String())
+
+		source, err := newSongSource(contentTypeCSV, "", strings.NewReader(b.String()))
+		assert.NoError(t, err)
+		record, err := source.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, []v1.SongItem{
+			{Type: "verse", Label: "Verse 1", Text: "Paranoia is in bloom"},
+			{Type: "chorus", Label: "Chorus x2", Text: "They will not force us"},
+		}, record.song.Text)
+	})
+
+	t.Run("empty csv", func(t *testing.T) {
+		var b strings.Builder
+		w := newExportWriter(exportCSV, &b)
+		assert.NoError(t, w.Close())
+		assert.Equal(t, "id,group,name,link,release_date,text,version,created_at,updated_at\n", b.String())
+	})
+
+	t.Run("zip", func(t *testing.T) {
+		var b bytes.Buffer
+		w := newExportWriter(exportZip, &b)
+		assert.NoError(t, w.Write(song))
+		assert.NoError(t, w.Close())
+
+		archive, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+		assert.NoError(t, err)
+		assert.Len(t, archive.File, 1)
+		assert.Equal(t, "songs/"+id.String()+".json", archive.File[0].Name)
+
+		f, err := archive.File[0].Open()
+		assert.NoError(t, err)
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		assert.NoError(t, err)
+		assert.JSONEq(t, exported, string(data))
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (s *Server) GetRevisions(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	revisions, err := s.service.GetRevisions(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toRevisionsResponse(revisions)})
+}
+
+func (s *Server) GetRevision(c *gin.Context) {
+	id, revision, err := toRevisionRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	rev, err := s.service.GetRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toRevisionResponse(rev)})
+}
+
+func (s *Server) RollbackRevision(c *gin.Context) {
+	id, revision, err := toRevisionRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	err = s.service.RollbackRevision(c.Request.Context(), id, revision)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
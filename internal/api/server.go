@@ -1,6 +1,14 @@
 package api
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/lyrics"
 	v1 "github.com/Alina9496/library/pkg/api/v1"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -39,60 +47,165 @@ func NewServer(handler *gin.Engine, l *logger.Logger, t Service) {
 		h.POST("/song", s.Create)
 		h.PATCH("/song/:id", s.Update)
 		h.DELETE("/song/:id", s.Delete)
+		h.GET("/song/:id", s.GetSong)
 		h.GET("/song", s.GetTextSong)
+		h.GET("/song/:id/lyrics", s.GetLyrics)
+		h.PUT("/song/:id/lrc", s.ImportLRC)
+		h.GET("/song/:id/lrc", s.ExportLRC)
+		h.PUT("/song/:id/chordpro", s.ImportChordPro)
+		h.GET("/song/:id/chordpro", s.ExportChordPro)
 		h.GET("/songs", s.GetSongs)
+		h.GET("/songs/search", s.SearchSongs)
+		h.GET("/suggest", s.Suggest)
+		h.POST("/songs/import", s.ImportSongs)
+		h.GET("/songs/export", s.ExportSongs)
+		h.POST("/lyrics/split", s.SplitLyrics)
+
+		h.GET("/song/:id/translations", s.GetTranslations)
+		h.POST("/song/:id/translations", s.CreateTranslation)
+		h.PUT("/song/:id/translations/:lang", s.UpdateTranslation)
+
+		h.GET("/song/:id/revisions", s.GetRevisions)
+		h.GET("/song/:id/revisions/:rev", s.GetRevision)
+		h.POST("/song/:id/revisions/:rev/rollback", s.RollbackRevision)
+
+		h.GET("/trash", s.GetTrash)
+		h.POST("/trash/:id/restore", s.Restore)
+		h.DELETE("/trash/:id", s.Purge)
+
+		h.POST("/artists", s.CreateArtist)
+		h.GET("/artists", s.GetArtists)
+		h.GET("/artists/:id", s.GetArtist)
+		h.PATCH("/artists/:id", s.UpdateArtist)
+		h.DELETE("/artists/:id", s.DeleteArtist)
+
+		h.POST("/albums", s.CreateAlbum)
+		h.GET("/albums", s.GetAlbums)
+		h.GET("/albums/:id", s.GetAlbum)
+		h.PATCH("/albums/:id", s.UpdateAlbum)
+		h.DELETE("/albums/:id", s.DeleteAlbum)
+		h.PUT("/albums/:id/tracks", s.SetAlbumTracks)
 	}
 }
 
+// Create adds the song. A request with the Idempotency-Key header is handled
+// once, repeats with the same body get the original response.
 func (s *Server) Create(c *gin.Context) {
-	var sg v1.Song
-	err := c.BindJSON(&sg)
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize))
 	if err != nil {
-		s.errorResponse(c, errToHttpStatus(err), err)
+		s.errorResponse(c, http.StatusRequestEntityTooLarge, err)
 		return
 	}
 
-	song, err := toDomainSong(sg)
+	key, err := toIdempotencyKey(c.GetHeader("Idempotency-Key"))
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
+	if key == "" {
+		code, resp := s.create(c, body)
+		c.JSON(code, resp)
+		return
+	}
 
-	id, err := s.service.Create(c.Request.Context(), song)
+	ctx := c.Request.Context()
+	owner, stored, err := s.service.BeginIdempotent(ctx, key, toRequestHash(c, body))
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
+	if stored != nil {
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(stored.Status, gin.MIMEJSON, stored.Response)
+		return
+	}
 
-	c.JSON(http.StatusOK, toRespID(id))
+	code, resp := s.create(c, body)
+	// the key is completed or released even when the client has gone,
+	// so that its retry gets the response
+	ctx = context.WithoutCancel(ctx)
+	response, err := json.Marshal(resp)
+	if err == nil && code < http.StatusInternalServerError {
+		err = s.service.CompleteIdempotent(ctx, key, owner, code, response)
+	} else {
+		err = s.service.ReleaseIdempotent(ctx, key, owner)
+	}
+	if err != nil {
+		s.l.Error(fmt.Errorf("api - Create - idempotency key: %w", err))
+	}
+
+	c.JSON(code, resp)
 }
 
+// create returns the status code and the body of the response to Create.
+// A text/x-chordpro body is a ChordPro file with the title and the artist.
+func (s *Server) create(c *gin.Context, body []byte) (int, any) {
+	var (
+		song *domain.Song
+		err  error
+	)
+	switch c.ContentType() {
+	case contentTypeChordPro:
+		song, err = toChordProSong(body)
+	case contentTypePlainText:
+		song, err = toPlainTextSong(c.Query("group"), c.Query("name"), body)
+	default:
+		var sg v1.Song
+		err = json.Unmarshal(body, &sg)
+		if err != nil {
+			return http.StatusBadRequest, toErrorResponse(err)
+		}
+		song, err = toDomainSong(sg)
+	}
+	if err != nil {
+		return errToHttpStatus(err), toErrorResponse(err)
+	}
+
+	id, err := s.service.Create(c.Request.Context(), song)
+	if err != nil {
+		return errToHttpStatus(err), toErrorResponse(err)
+	}
+
+	return http.StatusOK, toRespID(id)
+}
+
+// Update applies a JSON Merge Patch or, with the application/json-patch+json
+// content type, a JSON Patch to the song. With If-Match the song is changed
+// only in the given version.
 func (s *Server) Update(c *gin.Context) {
-	var sg v1.Song
-	err := c.BindJSON(&sg)
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	version, err := toIfMatch(c.GetHeader("If-Match"))
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
 
-	song, err := toDomainSong(sg)
+	apply, err := toPatchFunc(c.ContentType())
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
 
-	song.ID, err = uuid.Parse(c.Param("id"))
+	body, err := c.GetRawData()
 	if err != nil {
-		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
 
-	err = s.service.Update(c.Request.Context(), song)
+	version, err = s.service.Patch(c.Request.Context(), &id, version, func(song *domain.Song) error {
+		return toPatchedSong(song, body, apply)
+	})
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
 
+	c.Header("ETag", toETag(version))
 	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
 }
 
@@ -103,7 +216,13 @@ func (s *Server) Delete(c *gin.Context) {
 		return
 	}
 
-	err = s.service.Delete(c.Request.Context(), &uuid)
+	version, err := toIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	err = s.service.Delete(c.Request.Context(), &uuid, version)
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
@@ -119,13 +238,226 @@ func (s *Server) GetTextSong(c *gin.Context) {
 		return
 	}
 
-	text, err := s.service.GetTextSong(c, param)
+	result, err := s.service.GetTextSong(c, param)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	resp := map[string]any{
+		"response": result.Text,
+		"total":    result.Total,
+		"has_next": result.HasNext,
+	}
+	if param.Lang != "" {
+		resp["lang"] = param.Lang
+		resp["translation"] = result.Translation
+	}
+
+	c.Header("ETag", toETag(result.Version))
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetLyrics renders the whole lyrics of the song as plain text, Markdown or HTML.
+func (s *Server) GetLyrics(c *gin.Context) {
+	id, opts, err := toLyricsRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	song, err := s.service.GetSong(c.Request.Context(), id)
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
 
-	c.JSON(http.StatusOK, map[string]string{"response": text})
+	c.Header("ETag", toETag(song.Version))
+	c.Data(http.StatusOK, opts.Format.ContentType(), []byte(lyrics.Render(song.Text, opts)))
+}
+
+// ImportLRC times the lyrics lines by the LRC file in the body. The lines of
+// the file are matched with the lines of the lyrics in order.
+func (s *Server) ImportLRC(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	version, err := toIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize))
+	if err != nil {
+		s.errorResponse(c, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	timing, err := lyrics.ParseLRC(body)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	version, err = s.service.Patch(c.Request.Context(), &id, version, func(song *domain.Song) error {
+		return song.Text.SetTiming(timing)
+	})
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.Header("ETag", toETag(version))
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+// ExportLRC returns the timed lyrics as an LRC file,
+// with enhanced=true including the word times.
+func (s *Server) ExportLRC(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	enhanced, err := toFlag(c.Query("enhanced"), false)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	song, err := s.service.GetSong(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	lrc, err := lyrics.FormatLRC(song.Group, song.Name, song.Text, enhanced)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.Header("ETag", toETag(song.Version))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": song.Group + " - " + song.Name + ".lrc",
+	}))
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(lrc))
+}
+
+// SplitLyrics proposes the sections of the plain lyrics in the body
+// for review, nothing is stored.
+func (s *Server) SplitLyrics(c *gin.Context) {
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize))
+	if err != nil {
+		s.errorResponse(c, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	text := lyrics.Split(string(body))
+	if len(text) == 0 {
+		s.errorResponse(c, errToHttpStatus(ErrTextIsEmpty), ErrTextIsEmpty)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toSongTextResponse(text)})
+}
+
+// ImportChordPro replaces the lyrics and the chords of the song by the ChordPro
+// file in the body, the title and artist directives rename the song.
+func (s *Server) ImportChordPro(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	version, err := toIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxUploadSize))
+	if err != nil {
+		s.errorResponse(c, http.StatusRequestEntityTooLarge, err)
+		return
+	}
+
+	imported, err := toChordPro(body)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	version, err = s.service.Patch(c.Request.Context(), &id, version, func(song *domain.Song) error {
+		if imported.Name != "" {
+			song.Name = imported.Name
+		}
+		if imported.Group != "" {
+			song.Group = imported.Group
+		}
+		song.Text = imported.Text
+		return nil
+	})
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.Header("ETag", toETag(version))
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+// ExportChordPro returns the song as a ChordPro file,
+// transpose moves the chords by the number of semitones.
+func (s *Server) ExportChordPro(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	semitones, err := toTranspose(c.Query("transpose"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	song, err := s.service.GetSong(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.Header("ETag", toETag(song.Version))
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": song.Group + " - " + song.Name + ".cho",
+	}))
+	c.Data(http.StatusOK, contentTypeChordPro+"; charset=utf-8", []byte(lyrics.FormatChordPro(song, semitones)))
+}
+
+// GetSong returns the song with all its fields and lyrics,
+// the ETag is the version of the song for If-Match.
+func (s *Server) GetSong(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	song, err := s.service.GetSong(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.Header("ETag", toETag(song.Version))
+	c.JSON(http.StatusOK, map[string]any{"response": toSongResponse(song)})
 }
 
 func (s *Server) GetSongs(c *gin.Context) {
@@ -135,11 +467,59 @@ func (s *Server) GetSongs(c *gin.Context) {
 		return
 	}
 
-	songs, err := s.service.GetSongs(c, filter)
+	list, err := s.service.GetSongs(c, filter)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	resp := map[string]any{
+		"response": toGetSongsResponse(list.Songs),
+		"total":    list.Total,
+		"limit":    filter.Limit,
+	}
+	if filter.Keyset {
+		resp["next_cursor"] = toNextCursor(filter, list.Songs)
+	} else {
+		resp["offset"] = filter.Offset
+	}
+	if len(filter.Facets) > 0 {
+		resp["facets"] = toFacetsResponse(filter.Facets, list.Facets)
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func (s *Server) SearchSongs(c *gin.Context) {
+	filter, err := toSearchRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	results, err := s.service.SearchSongs(c, filter)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toSearchResponse(results)})
+}
+
+// Suggest returns the song names and the groups starting with or similar to
+// the query for a type-ahead.
+func (s *Server) Suggest(c *gin.Context) {
+	filter, err := toSuggestRequest(c)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	suggestions, err := s.service.Suggest(c, filter)
 	if err != nil {
 		s.errorResponse(c, errToHttpStatus(err), err)
 		return
 	}
 
-	c.JSON(http.StatusOK, map[string]any{"response": toGetSongsResponse(songs)})
+	c.JSON(http.StatusOK, map[string]any{"response": toSuggestResponse(suggestions)})
 }
@@ -0,0 +1,85 @@
+package api
+
+import (
+	"net/http"
+
+	v1 "github.com/Alina9496/library/pkg/api/v1"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateTranslation adds the translation of the song to the language in the body.
+func (s *Server) CreateTranslation(c *gin.Context) {
+	var t v1.Translation
+	err := c.BindJSON(&t)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	translation, err := toDomainTranslation(id, t.Lang, t)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	err = s.service.CreateTranslation(c.Request.Context(), translation)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+// UpdateTranslation replaces the translation to the language in the path.
+func (s *Server) UpdateTranslation(c *gin.Context) {
+	var t v1.Translation
+	err := c.BindJSON(&t)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	translation, err := toDomainTranslation(id, c.Param("lang"), t)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	err = s.service.UpdateTranslation(c.Request.Context(), translation)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]string{"response": "ok"})
+}
+
+func (s *Server) GetTranslations(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(ErrParsingID), ErrParsingID)
+		return
+	}
+
+	translations, err := s.service.GetTranslations(c.Request.Context(), &id)
+	if err != nil {
+		s.errorResponse(c, errToHttpStatus(err), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, map[string]any{"response": toTranslationsResponse(translations)})
+}
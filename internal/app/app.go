@@ -2,6 +2,7 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
@@ -13,6 +14,7 @@ import (
 	"github.com/Alina9496/library/internal/api"
 	"github.com/Alina9496/library/internal/repo"
 	"github.com/Alina9496/library/internal/service"
+	"github.com/Alina9496/library/internal/songinfo"
 	"github.com/Alina9496/tool/pkg/httpserver"
 	"github.com/Alina9496/tool/pkg/logger"
 	"github.com/Alina9496/tool/pkg/postgres"
@@ -25,7 +27,8 @@ func Run(cfg *config.Config) {
 	runDatabaseMigration(cfg.PG.URL)
 
 	// Repository
-	pg, err := postgres.New(cfg.PG.URL)
+	// a streaming export holds a connection for the whole response
+	pg, err := postgres.New(cfg.PG.URL, postgres.MaxPoolSize(cfg.PG.PoolMax))
 	if err != nil {
 		l.Fatal(err)
 	}
@@ -34,9 +37,25 @@ func Run(cfg *config.Config) {
 	// Use case
 	service := service.New(
 		repo.New(pg, l),
+		songinfo.New(
+			cfg.SongInfo.URL,
+			songinfo.Timeout(cfg.SongInfo.Timeout),
+			songinfo.Retries(cfg.SongInfo.Retries),
+			songinfo.RetryDelay(cfg.SongInfo.RetryDelay),
+		),
 		l,
+		service.TrashRetention(cfg.Trash.Retention),
+		service.IdempotencyTTL(cfg.Idempotency.TTL),
+		service.IdempotencyLease(cfg.Idempotency.Lease),
+		service.SearchSimilarity(cfg.Search.Similarity),
+		service.SuggestTTL(cfg.Search.SuggestTTL),
 	)
 
+	// Trash and idempotency keys purge
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go runPurge(ctx, l, service, cfg.Trash.PurgeInterval)
+
 	// HTTP Server
 	handler := gin.New()
 	api.NewServer(handler, l, service)
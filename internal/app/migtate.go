@@ -41,6 +41,10 @@ func runDatabaseMigration(databaseURL string) {
 
 	err = m.Up()
 	defer m.Close()
+	var dirty migrate.ErrDirty
+	if errors.As(err, &dirty) {
+		log.Fatalf("Migrate: version %d failed and is marked dirty, fix the cause and run `migrate force %d`", dirty.Version, dirty.Version-1)
+	}
 	if err != nil && !errors.Is(err, migrate.ErrNoChange) {
 		log.Fatalf("Migrate: up error: %s", err)
 	}
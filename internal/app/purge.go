@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/service"
+	"github.com/Alina9496/tool/pkg/logger"
+)
+
+// runPurge periodically removes songs whose trash retention has expired
+// and idempotency keys older than their replay window.
+func runPurge(ctx context.Context, l *logger.Logger, s *service.Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_, err := s.PurgeExpired(ctx)
+			if err != nil {
+				l.Error(fmt.Errorf("app - runPurge - s.PurgeExpired: %w", err))
+			}
+
+			_, err = s.PurgeIdempotencyKeys(ctx)
+			if err != nil {
+				l.Error(fmt.Errorf("app - runPurge - s.PurgeIdempotencyKeys: %w", err))
+			}
+		}
+	}
+}
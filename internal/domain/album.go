@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Album struct {
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ReleaseDate time.Time
+	ID          uuid.UUID
+	ArtistID    uuid.UUID
+	Artist      string
+	Title       string
+	Tracks      []AlbumTrack
+}
+
+// AlbumPatch is a partial update of the album details, nil fields are kept.
+// The artist is set by ArtistID or by the name in Artist.
+type AlbumPatch struct {
+	ID          uuid.UUID
+	Title       *string
+	ArtistID    *uuid.UUID
+	Artist      *string
+	ReleaseDate *time.Time
+}
+
+// AlbumTrack is a song at its 1-based position in the album.
+type AlbumTrack struct {
+	Position int
+	SongID   uuid.UUID
+	Name     string
+}
+
+type AlbumRequest struct {
+	ArtistID uuid.UUID
+	Limit    int
+	Offset   int
+	Title    string
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Artist struct {
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	ID          uuid.UUID
+	Name        string
+	Description string
+}
+
+// ArtistPatch is a partial update of an artist, nil fields are kept.
+type ArtistPatch struct {
+	ID          uuid.UUID
+	Name        *string
+	Description *string
+}
+
+type ArtistRequest struct {
+	Limit  int
+	Offset int
+	Name   string
+}
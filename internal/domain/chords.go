@@ -0,0 +1,9 @@
+package domain
+
+// Chord is a chord placed over a lyrics line. Line is the index in the
+// lines of the section, Pos is the offset in runes from the line start.
+type Chord struct {
+	Line int    `json:"line"`
+	Pos  int    `json:"pos"`
+	Name string `json:"name"`
+}
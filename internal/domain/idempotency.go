@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey keeps the response to the request sent with the key.
+// Status is zero while the request is in progress, Owner is the token
+// of the request holding the key.
+type IdempotencyKey struct {
+	Key         string
+	Owner       uuid.UUID
+	RequestHash string
+	Status      int
+	Response    []byte
+	CreatedAt   time.Time
+}
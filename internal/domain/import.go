@@ -0,0 +1,21 @@
+package domain
+
+import "github.com/google/uuid"
+
+type ImportStatus string
+
+const (
+	ImportCreated   ImportStatus = "created"
+	ImportDuplicate ImportStatus = "duplicate"
+	ImportInvalid   ImportStatus = "invalid"
+	ImportFailed    ImportStatus = "failed"
+)
+
+// ImportResult is the outcome of a song of a bulk import. ID is set for
+// created songs, Err explains why an invalid or failed song was not created.
+// A song fails when the import stops at it or in its batch.
+type ImportResult struct {
+	Status ImportStatus
+	ID     uuid.UUID
+	Err    error
+}
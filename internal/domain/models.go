@@ -11,25 +11,91 @@ import (
 
 type TypeSongItem string
 
-const Verse TypeSongItem = "verse"
-const Chorus TypeSongItem = "chorus"
+const (
+	Intro     TypeSongItem = "intro"
+	Verse     TypeSongItem = "verse"
+	PreChorus TypeSongItem = "pre-chorus"
+	Chorus    TypeSongItem = "chorus"
+	Refrain   TypeSongItem = "refrain"
+	Hook      TypeSongItem = "hook"
+	Bridge    TypeSongItem = "bridge"
+	Outro     TypeSongItem = "outro"
+)
+
+// IsValid reports whether the section type is known.
+func (t TypeSongItem) IsValid() bool {
+	switch t {
+	case Intro, Verse, PreChorus, Chorus, Refrain, Hook, Bridge, Outro:
+		return true
+	}
+	return false
+}
 
+// SongItem is a section of the lyrics. Label is an optional caption
+// such as "Verse 2" or "Chorus (x2)", Timing is set by an LRC import
+// and Chords by a ChordPro import.
 type SongItem struct {
-	Type TypeSongItem `json:"type,omitempty"`
-	Text string       `json:"text,omitempty"`
+	Type   TypeSongItem `json:"type,omitempty"`
+	Label  string       `json:"label,omitempty"`
+	Text   string       `json:"text,omitempty"`
+	Timing []TimedLine  `json:"timing,omitempty"`
+	Chords []Chord      `json:"chords,omitempty"`
 }
 
 type SongText []SongItem
 
+// WithoutAnnotations returns a copy of the lyrics without the timing
+// and the chords.
+func (s SongText) WithoutAnnotations() SongText {
+	if s == nil {
+		return nil
+	}
+	text := make(SongText, 0, len(s))
+	for _, item := range s {
+		item.Timing, item.Chords = nil, nil
+		text = append(text, item)
+	}
+	return text
+}
+
+// KeepAnnotations copies the missing timing and chords of the previous lyrics
+// to the sections which are left at their place without changes. The timing
+// and the chords the sections already have are never dropped.
+func (s SongText) KeepAnnotations(prev SongText) {
+	for i := range s {
+		if i >= len(prev) || s[i].Type != prev[i].Type || s[i].Text != prev[i].Text {
+			continue
+		}
+		if s[i].Timing == nil {
+			s[i].Timing = prev[i].Timing
+		}
+		if s[i].Chords == nil {
+			s[i].Chords = prev[i].Chords
+		}
+	}
+}
+
 type Song struct {
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   time.Time
 	ReleaseDate time.Time
 	ID          uuid.UUID
+	ArtistID    uuid.UUID
+	AlbumID     uuid.UUID
 	Text        SongText
 	Name        string
 	Group       string
 	Link        string
+	Version     int
+
+	FeaturedArtistIDs []uuid.UUID
+}
+
+// IsIncomplete reports whether the song misses details
+// that can be requested from the song info provider.
+func (s *Song) IsIncomplete() bool {
+	return len(s.Text) == 0 || s.Link == "" || s.ReleaseDate.IsZero()
 }
 
 func (s SongText) Value() (driver.Value, error) {
@@ -46,7 +112,7 @@ func (s *SongText) Scan(value any) error {
 
 func (s SongText) IsValidType() bool {
 	for _, v := range s {
-		if v.Type != Verse && v.Type != Chorus {
+		if !v.Type.IsValid() {
 			return false
 		}
 	}
@@ -55,9 +121,152 @@ func (s SongText) IsValidType() bool {
 
 type SongRequest struct {
 	ReleaseDate time.Time
+	After       *SongCursor
+	Text        TextAddress
+	ArtistID    uuid.UUID
+	AlbumID     uuid.UUID
+	Keyset      bool
 	Limit       int
 	Offset      int
 	Name        string
 	Group       string
 	Link        string
+	// Lang asks GetTextSong for the translation next to the original.
+	Lang string
+	// ReleaseFrom and ReleaseTo bound the release date, both inclusive.
+	ReleaseFrom time.Time
+	ReleaseTo   time.Time
+	// Year matches the songs released in the year.
+	Year         int
+	CreatedSince time.Time
+	UpdatedSince time.Time
+	// Sort orders the songs by the keys in turn, by default by (created_at, id).
+	Sort []SongSort
+	// Facets asks GetSongs to count the matching songs by the fields.
+	Facets []SongFacet
+	// Similarity is the least word similarity of a fuzzy match of Group and Name.
+	Similarity float64
+}
+
+// TextAddress points to a range of sections of the lyrics and to a range of
+// lines inside a single section. Indexes start from one, a zero end of a range
+// means its start and zero lines mean whole sections.
+type TextAddress struct {
+	// Section counts only the sections of the type, the empty one counts all.
+	Section  TypeSongItem
+	From     int
+	To       int
+	LineFrom int
+	LineTo   int
+}
+
+// SongTextResult is a part of the lyrics with the version of the song.
+// Total is the number of sections addressed by the type, HasNext reports
+// whether sections follow the returned ones. Translation is the same part
+// of the translation when a language was requested.
+type SongTextResult struct {
+	Text        string
+	Translation string
+	Version     int
+	Total       int
+	HasNext     bool
+}
+
+// SongSortField is a field the song list can be sorted by.
+type SongSortField string
+
+const (
+	SortName        SongSortField = "name"
+	SortGroup       SongSortField = "group"
+	SortReleaseDate SongSortField = "release_date"
+	SortCreatedAt   SongSortField = "created_at"
+)
+
+// SongSort is a key of the song list order.
+type SongSort struct {
+	Field SongSortField
+	Desc  bool
+}
+
+// SongFacet is a field the matching songs of the list are counted by.
+type SongFacet string
+
+const (
+	FacetGroup   SongFacet = "group"
+	FacetYear    SongFacet = "year"
+	FacetSection SongFacet = "section"
+)
+
+// SongList is a page of the song list with the number of all the matching
+// songs and their counts by the requested facets.
+type SongList struct {
+	Songs  []Song
+	Total  int
+	Facets map[SongFacet][]FacetCount
+}
+
+// FacetCount is the number of the matching songs with the value of a facet,
+// the counts go from the largest.
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// SongCursor is the position of the last song of a page in the (created_at, id) order.
+type SongCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+type TrashRequest struct {
+	Limit  int
+	Offset int
+}
+
+type SearchRequest struct {
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// SuggestionKind is the kind of the entity of a suggestion.
+type SuggestionKind string
+
+const (
+	SuggestSong  SuggestionKind = "song"
+	SuggestGroup SuggestionKind = "group"
+)
+
+type SuggestRequest struct {
+	Query string
+	Limit int
+	// Similarity is the least word similarity of a suggestion to the query.
+	Similarity float64
+}
+
+// Suggestion is a song name or a group starting with or similar to the query
+// of a type-ahead. Group is the group of a song.
+type Suggestion struct {
+	Kind  SuggestionKind
+	ID    uuid.UUID
+	Text  string
+	Group string
+}
+
+// SnippetStart and SnippetStop enclose the matched words of a snippet,
+// they are not HTML so that the lyrics can be escaped around them.
+const (
+	SnippetStart = "\x02"
+	SnippetStop  = "\x03"
+)
+
+// SearchResult is a song matched by lyrics with the best matching section.
+type SearchResult struct {
+	ID           uuid.UUID
+	Name         string
+	Group        string
+	Rank         float64
+	SectionIndex int
+	SectionType  TypeSongItem
+	Snippet      string
 }
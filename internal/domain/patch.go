@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SongPatch holds the changed fields of the song, nil fields are kept.
+type SongPatch struct {
+	ReleaseDate       *time.Time
+	ArtistID          *uuid.UUID
+	Text              *SongText
+	Name              *string
+	Group             *string
+	Link              *string
+	FeaturedArtistIDs *[]uuid.UUID
+}
+
+// Diff returns the fields of the patched song that differ from s.
+func (s *Song) Diff(patched *Song) *SongPatch {
+	var p SongPatch
+	if !patched.ReleaseDate.Equal(s.ReleaseDate) {
+		p.ReleaseDate = &patched.ReleaseDate
+	}
+	if patched.ArtistID != s.ArtistID {
+		p.ArtistID = &patched.ArtistID
+	}
+	if !reflect.DeepEqual(patched.Text, s.Text) {
+		p.Text = &patched.Text
+	}
+	if patched.Name != s.Name {
+		p.Name = &patched.Name
+	}
+	if patched.Group != s.Group {
+		p.Group = &patched.Group
+	}
+	if patched.Link != s.Link {
+		p.Link = &patched.Link
+	}
+	if len(patched.FeaturedArtistIDs) != 0 || len(s.FeaturedArtistIDs) != 0 {
+		if !reflect.DeepEqual(patched.FeaturedArtistIDs, s.FeaturedArtistIDs) {
+			p.FeaturedArtistIDs = &patched.FeaturedArtistIDs
+		}
+	}
+	return &p
+}
+
+// IsEmpty reports whether the patch changes nothing.
+func (p *SongPatch) IsEmpty() bool {
+	return *p == SongPatch{}
+}
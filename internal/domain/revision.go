@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// SongRevision is a snapshot of the song taken after each change.
+type SongRevision struct {
+	CreatedAt time.Time
+	Song      Song
+	Revision  int
+}
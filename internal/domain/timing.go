@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrTimingMismatch = errors.New("timed lines don't match the lyrics")
+
+// TimedLine is the start of a lyrics line in milliseconds. Words hold the
+// starts of its words for karaoke and are optional.
+type TimedLine struct {
+	Start int64       `json:"start"`
+	Words []TimedWord `json:"words,omitempty"`
+}
+
+// TimedWord is the start of a word in milliseconds.
+type TimedWord struct {
+	Start int64  `json:"start"`
+	Text  string `json:"text"`
+}
+
+// Lines returns the non-blank lines of the section, the timing has an entry
+// for each of them and the chords refer to them by index.
+func (i SongItem) Lines() []string {
+	lines := make([]string, 0, strings.Count(i.Text, "\n")+1)
+	for _, line := range strings.Split(i.Text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// SetTiming distributes the timed lines over the lines of the sections in order.
+func (s SongText) SetTiming(timing []TimedLine) error {
+	total := 0
+	for _, item := range s {
+		total += len(item.Lines())
+	}
+	if total != len(timing) {
+		return ErrTimingMismatch
+	}
+
+	for i, item := range s {
+		n := len(item.Lines())
+		s[i].Timing = timing[:n:n]
+		timing = timing[n:]
+	}
+	return nil
+}
+
+// HasTiming reports whether every line of the lyrics is timed.
+func (s SongText) HasTiming() bool {
+	for _, item := range s {
+		if len(item.Timing) != len(item.Lines()) {
+			return false
+		}
+	}
+	return len(s) > 0
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrTranslationMismatch = errors.New("translation sections don't match the lyrics")
+
+// Translation is the lyrics in another language, Lang is a BCP 47 tag.
+// Its sections are aligned with the sections of the original lyrics,
+// Sources keeps the hashes of the original sections it was made for.
+type Translation struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	SongID    uuid.UUID
+	Lang      string
+	Text      SongText
+	Sources   []string
+}
+
+// AlignTo checks that the translation has a section for each section of the
+// original and gives them the types of the original ones. A section of the
+// translation may omit its type.
+func (s SongText) AlignTo(original SongText) error {
+	if len(s) != len(original) {
+		return ErrTranslationMismatch
+	}
+	for i := range s {
+		if s[i].Type != "" && s[i].Type != original[i].Type {
+			return ErrTranslationMismatch
+		}
+		s[i].Type = original[i].Type
+	}
+	return nil
+}
+
+// SourceHashes returns the hashes of the sections, which tell whether
+// the section a translation was made for has changed since.
+func (s SongText) SourceHashes() []string {
+	hashes := make([]string, 0, len(s))
+	for _, item := range s {
+		sum := sha256.Sum256([]byte(item.Text))
+		hashes = append(hashes, hex.EncodeToString(sum[:]))
+	}
+	return hashes
+}
+
+// Aligned returns the translation of each section of the original lyrics.
+// The sections which have no translation since the lyrics were changed
+// are left in the original. Translations saved without the hashes of
+// their sources are matched by the type of the sections only.
+func (t *Translation) Aligned(original SongText) SongText {
+	hashes := original.SourceHashes()
+	aligned := make(SongText, len(original))
+	for i, item := range original {
+		aligned[i] = item
+		if i >= len(t.Text) || t.Text[i].Type != item.Type {
+			continue
+		}
+		if t.Sources != nil && (i >= len(t.Sources) || t.Sources[i] != hashes[i]) {
+			continue
+		}
+		aligned[i] = t.Text[i]
+	}
+	return aligned
+}
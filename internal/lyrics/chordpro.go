@@ -0,0 +1,335 @@
+package lyrics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+var ErrInvalidChordPro = errors.New("invalid chordpro")
+
+// shortSections are the environments of the short directives such as {soc}.
+var shortSections = map[string]string{
+	"c": "chorus",
+	"v": "verse",
+	"b": "bridge",
+	"t": "tab",
+	"g": "grid",
+}
+
+// ParseChordPro returns the song of a ChordPro file. The title and artist
+// directives set the name and the group. {start_of_X} environments become
+// sections of the type X, lines outside of them form verses split by blank
+// lines, environments of unknown types such as tabs are skipped. Chords of
+// the lines without lyrics are moved to the start of the next line.
+func ParseChordPro(data []byte) (*domain.Song, error) {
+	var p chordProParser
+
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	for n := 1; scanner.Scan(); n++ {
+		err := p.parseLine(strings.TrimSpace(scanner.Text()))
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidChordPro, n, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidChordPro, err)
+	}
+	if p.env != "" {
+		return nil, fmt.Errorf("%w: {start_of_%s} is not closed", ErrInvalidChordPro, p.env)
+	}
+	p.flush()
+	if len(p.song.Text) == 0 {
+		return nil, fmt.Errorf("%w: no lyrics", ErrInvalidChordPro)
+	}
+	return &p.song, nil
+}
+
+type chordProParser struct {
+	song domain.Song
+	// env is the open environment, empty outside of them.
+	env  string
+	skip bool
+	// section is the section being read, nil between sections.
+	section *domain.SongItem
+	lines   []string
+	// pending are the chords of the lines without lyrics.
+	pending []domain.Chord
+	chorus  *domain.SongItem
+}
+
+func (p *chordProParser) parseLine(line string) error {
+	switch {
+	case line == "":
+		if p.env == "" {
+			p.flush()
+		}
+		return nil
+	case strings.HasPrefix(line, "#"):
+		return nil
+	case strings.HasPrefix(line, "{"):
+		if !strings.HasSuffix(line, "}") {
+			return errors.New("directive is not closed")
+		}
+		return p.directive(line[1 : len(line)-1])
+	}
+
+	if p.skip {
+		return nil
+	}
+	text, chords, err := parseChordLine(line)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		p.pending = append(p.pending, chords...)
+		return nil
+	}
+
+	if p.section == nil {
+		p.section = &domain.SongItem{Type: domain.Verse, Chords: []domain.Chord{}}
+	}
+	for _, chord := range p.pending {
+		chord.Line, chord.Pos = len(p.lines), 0
+		p.section.Chords = append(p.section.Chords, chord)
+	}
+	for _, chord := range chords {
+		chord.Line = len(p.lines)
+		p.section.Chords = append(p.section.Chords, chord)
+	}
+	p.pending = nil
+	p.lines = append(p.lines, text)
+	return nil
+}
+
+func (p *chordProParser) directive(directive string) error {
+	name, value, ok := strings.Cut(directive, ":")
+	if !ok {
+		name, value, _ = strings.Cut(directive, " ")
+	}
+	name, value = strings.ToLower(strings.TrimSpace(name)), strings.TrimSpace(value)
+
+	if env, ok := sectionEnv(name, "start_of_", "so"); ok {
+		return p.start(env, value)
+	}
+	if env, ok := sectionEnv(name, "end_of_", "eo"); ok {
+		return p.end(env)
+	}
+
+	switch name {
+	case "title", "t":
+		p.song.Name = value
+	case "artist":
+		p.song.Group = value
+	case "chorus":
+		p.flush()
+		if p.chorus != nil {
+			chorus := *p.chorus
+			chorus.Chords = append([]domain.Chord{}, p.chorus.Chords...)
+			p.song.Text = append(p.song.Text, chorus)
+		}
+	}
+	return nil
+}
+
+func (p *chordProParser) start(env, value string) error {
+	if p.env != "" {
+		return fmt.Errorf("{start_of_%s} is not closed", p.env)
+	}
+	p.flush()
+	p.env = env
+	typ := domain.TypeSongItem(strings.ReplaceAll(env, "_", "-"))
+	if !typ.IsValid() {
+		p.skip = true
+		return nil
+	}
+	p.section = &domain.SongItem{Type: typ, Label: sectionLabel(value), Chords: []domain.Chord{}}
+	return nil
+}
+
+func (p *chordProParser) end(env string) error {
+	if env != p.env {
+		return fmt.Errorf("{end_of_%s} without {start_of_%s}", env, env)
+	}
+	p.flush()
+	p.env, p.skip = "", false
+	return nil
+}
+
+// flush ends the current section, the chords left without a line
+// are put at the end of the last line.
+func (p *chordProParser) flush() {
+	section := p.section
+	p.section = nil
+	if section == nil || len(p.lines) == 0 {
+		p.lines, p.pending = nil, nil
+		return
+	}
+
+	last := len(p.lines) - 1
+	for _, chord := range p.pending {
+		chord.Line, chord.Pos = last, utf8.RuneCountInString(p.lines[last])
+		section.Chords = append(section.Chords, chord)
+	}
+	section.Text = strings.Join(p.lines, "\n")
+	p.song.Text = append(p.song.Text, *section)
+	if section.Type == domain.Chorus {
+		p.chorus = section
+	}
+	p.lines, p.pending = nil, nil
+}
+
+// sectionEnv returns the environment of a directive with the long prefix
+// such as start_of_chorus or the short one such as soc.
+func sectionEnv(name, long, short string) (string, bool) {
+	if env, ok := strings.CutPrefix(name, long); ok && env != "" {
+		return env, true
+	}
+	if key, ok := strings.CutPrefix(name, short); ok {
+		env, ok := shortSections[key]
+		return env, ok
+	}
+	return "", false
+}
+
+// sectionLabel returns the label of {start_of_verse: Verse 2}
+// or {start_of_verse label="Verse 2"}.
+func sectionLabel(value string) string {
+	if label, ok := strings.CutPrefix(value, "label="); ok {
+		return strings.Trim(label, `"'`)
+	}
+	return value
+}
+
+// parseChordLine returns the lyrics of a line with the chords placed
+// in it, such as "[Am]I wanna [G]hold 'em".
+func parseChordLine(line string) (string, []domain.Chord, error) {
+	var (
+		b      strings.Builder
+		chords []domain.Chord
+		pos    int
+	)
+	for line != "" {
+		start := strings.IndexByte(line, '[')
+		if start < 0 {
+			b.WriteString(line)
+			pos += utf8.RuneCountInString(line)
+			break
+		}
+		b.WriteString(line[:start])
+		pos += utf8.RuneCountInString(line[:start])
+
+		end := strings.IndexByte(line[start:], ']')
+		if end < 0 {
+			return "", nil, errors.New("chord is not closed")
+		}
+		if name := strings.TrimSpace(line[start+1 : start+end]); name != "" {
+			chords = append(chords, domain.Chord{Pos: pos, Name: name})
+		}
+		line = line[start+end+1:]
+	}
+
+	text := b.String()
+	trimmed := strings.TrimLeftFunc(text, unicode.IsSpace)
+	shift := utf8.RuneCountInString(text) - utf8.RuneCountInString(trimmed)
+	trimmed = strings.TrimRightFunc(trimmed, unicode.IsSpace)
+	length := utf8.RuneCountInString(trimmed)
+	for i := range chords {
+		chords[i].Pos = min(max(chords[i].Pos-shift, 0), length)
+	}
+	return trimmed, chords, nil
+}
+
+// FormatChordPro returns the song as a ChordPro file
+// with the chords transposed by the semitones.
+func FormatChordPro(song *domain.Song, semitones int) string {
+	var b strings.Builder
+	b.WriteString("{title: " + song.Name + "}\n")
+	b.WriteString("{artist: " + song.Group + "}\n")
+	for _, item := range song.Text {
+		env := strings.ReplaceAll(string(item.Type), "-", "_")
+		b.WriteString("\n{start_of_" + env)
+		if item.Label != "" {
+			b.WriteString(": " + item.Label)
+		}
+		b.WriteString("}\n")
+
+		for i, line := range item.Lines() {
+			var chords []domain.Chord
+			for _, chord := range item.Chords {
+				if chord.Line == i {
+					chords = append(chords, chord)
+				}
+			}
+			sort.SliceStable(chords, func(a, b int) bool { return chords[a].Pos < chords[b].Pos })
+			b.WriteString(placeChords(line, chords, semitones) + "\n")
+		}
+		b.WriteString("{end_of_" + env + "}\n")
+	}
+	return b.String()
+}
+
+// placeChords puts the chords in brackets before their positions in the line.
+func placeChords(line string, chords []domain.Chord, semitones int) string {
+	var b strings.Builder
+	runes := []rune(line)
+	next := 0
+	for pos := 0; pos <= len(runes); pos++ {
+		for next < len(chords) && min(chords[next].Pos, len(runes)) <= pos {
+			b.WriteString("[" + Transpose(chords[next].Name, semitones) + "]")
+			next++
+		}
+		if pos < len(runes) {
+			b.WriteRune(runes[pos])
+		}
+	}
+	return b.String()
+}
+
+var (
+	noteRe     = regexp.MustCompile(`^([A-G])([#b]?)(.*)$`)
+	notes      = map[string]int{"C": 0, "D": 2, "E": 4, "F": 5, "G": 7, "A": 9, "B": 11}
+	sharpNotes = []string{"C", "C#", "D", "D#", "E", "F", "F#", "G", "G#", "A", "A#", "B"}
+	flatNotes  = []string{"C", "Db", "D", "Eb", "E", "F", "Gb", "G", "Ab", "A", "Bb", "B"}
+)
+
+// Transpose moves the root and the bass note of the chord by the semitones,
+// a flat chord stays flat. Annotations which are not chords are kept.
+func Transpose(chord string, semitones int) string {
+	if semitones%12 == 0 {
+		return chord
+	}
+	root, bass, slash := strings.Cut(chord, "/")
+	root = transposeNote(root, semitones)
+	if slash {
+		root += "/" + transposeNote(bass, semitones)
+	}
+	return root
+}
+
+func transposeNote(s string, semitones int) string {
+	m := noteRe.FindStringSubmatch(s)
+	if m == nil {
+		return s
+	}
+
+	note := notes[m[1]]
+	names := sharpNotes
+	switch m[2] {
+	case "#":
+		note++
+	case "b":
+		note--
+		names = flatNotes
+	}
+	note = ((note+semitones)%12 + 12) % 12
+	return names[note] + m[3]
+}
@@ -0,0 +1,157 @@
+package lyrics
+
+import (
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChordPro(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *domain.Song
+		wantErr bool
+	}{
+		{
+			name: "directives and sections",
+			data: "{title: Poker Face}\n{artist: Lady Gaga}\n# intro\n{c: slowly}\n" +
+				"[Am]I wanna hold 'em like they [G]do in Texas\n[C]Fold 'em\n\n" +
+				"{start_of_chorus: Chorus (x2)}\n[Am]Can't read my, [F]can't read my\n{end_of_chorus}\n" +
+				"{sov}\n  [C]   I wanna [G]roll with him [Am]\n{eov}\n" +
+				"{chorus}\n",
+			want: &domain.Song{
+				Name:  "Poker Face",
+				Group: "Lady Gaga",
+				Text: domain.SongText{
+					{Type: domain.Verse, Text: "I wanna hold 'em like they do in Texas\nFold 'em", Chords: []domain.Chord{
+						{Line: 0, Pos: 0, Name: "Am"},
+						{Line: 0, Pos: 27, Name: "G"},
+						{Line: 1, Pos: 0, Name: "C"},
+					}},
+					{Type: domain.Chorus, Label: "Chorus (x2)", Text: "Can't read my, can't read my", Chords: []domain.Chord{
+						{Line: 0, Pos: 0, Name: "Am"},
+						{Line: 0, Pos: 15, Name: "F"},
+					}},
+					{Type: domain.Verse, Text: "I wanna roll with him", Chords: []domain.Chord{
+						{Line: 0, Pos: 0, Name: "C"},
+						{Line: 0, Pos: 8, Name: "G"},
+						{Line: 0, Pos: 21, Name: "Am"},
+					}},
+					{Type: domain.Chorus, Label: "Chorus (x2)", Text: "Can't read my, can't read my", Chords: []domain.Chord{
+						{Line: 0, Pos: 0, Name: "Am"},
+						{Line: 0, Pos: 15, Name: "F"},
+					}},
+				},
+			},
+		},
+		{
+			name: "instrumental lines and skipped tabs",
+			data: "{start_of_pre_chorus label=\"Build\"}\n[Em] [D]\nI won't tell you\n[C]\n{end_of_pre_chorus}\n" +
+				"{start_of_tab}\ne|---0---|\n{end_of_tab}\n",
+			want: &domain.Song{
+				Text: domain.SongText{
+					{Type: domain.PreChorus, Label: "Build", Text: "I won't tell you", Chords: []domain.Chord{
+						{Line: 0, Pos: 0, Name: "Em"},
+						{Line: 0, Pos: 0, Name: "D"},
+						{Line: 0, Pos: 16, Name: "C"},
+					}},
+				},
+			},
+		},
+		{
+			name:    "chord is not closed",
+			data:    "[Am I wanna hold 'em",
+			wantErr: true,
+		},
+		{
+			name:    "section is not closed",
+			data:    "{soc}\nCan't read my",
+			wantErr: true,
+		},
+		{
+			name:    "nested sections",
+			data:    "{soc}\n{sov}\nCan't read my\n{eov}\n{eoc}",
+			wantErr: true,
+		},
+		{
+			name:    "end without start",
+			data:    "Can't read my\n{eoc}",
+			wantErr: true,
+		},
+		{
+			name:    "no lyrics",
+			data:    "{title: Poker Face}\n[Am] [G]",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseChordPro([]byte(tt.data))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidChordPro)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatChordPro(t *testing.T) {
+	song := &domain.Song{
+		Name:  "Poker Face",
+		Group: "Lady Gaga",
+		Text: domain.SongText{
+			{Type: domain.Verse, Text: "I wanna hold 'em\nFold 'em", Chords: []domain.Chord{
+				{Line: 0, Pos: 8, Name: "G"},
+				{Line: 0, Pos: 0, Name: "Am"},
+				{Line: 1, Pos: 8, Name: "C/E"},
+			}},
+			{Type: domain.PreChorus, Label: "Build", Text: "I won't tell you"},
+		},
+	}
+
+	want := "{title: Poker Face}\n{artist: Lady Gaga}\n" +
+		"\n{start_of_verse}\n[Am]I wanna [G]hold 'em\nFold 'em[C/E]\n{end_of_verse}\n" +
+		"\n{start_of_pre_chorus: Build}\nI won't tell you\n{end_of_pre_chorus}\n"
+	assert.Equal(t, want, FormatChordPro(song, 0))
+
+	transposed := "{title: Poker Face}\n{artist: Lady Gaga}\n" +
+		"\n{start_of_verse}\n[Bm]I wanna [A]hold 'em\nFold 'em[D/F#]\n{end_of_verse}\n" +
+		"\n{start_of_pre_chorus: Build}\nI won't tell you\n{end_of_pre_chorus}\n"
+	assert.Equal(t, transposed, FormatChordPro(song, 2))
+
+	parsed, err := ParseChordPro([]byte(want))
+	assert.NoError(t, err)
+	assert.Equal(t, want, FormatChordPro(parsed, 0))
+}
+
+func TestTranspose(t *testing.T) {
+	tests := []struct {
+		chord     string
+		semitones int
+		want      string
+	}{
+		{chord: "Am", semitones: 0, want: "Am"},
+		{chord: "Am", semitones: 3, want: "Cm"},
+		{chord: "E7", semitones: 1, want: "F7"},
+		{chord: "B", semitones: 1, want: "C"},
+		{chord: "C", semitones: -1, want: "B"},
+		{chord: "F#m7b5", semitones: 2, want: "G#m7b5"},
+		{chord: "Bb", semitones: 2, want: "C"},
+		{chord: "Eb", semitones: 1, want: "E"},
+		{chord: "Ab", semitones: 1, want: "A"},
+		{chord: "Db", semitones: -3, want: "Bb"},
+		{chord: "G/B", semitones: -2, want: "F/A"},
+		{chord: "Dsus4", semitones: 12, want: "Dsus4"},
+		{chord: "Dsus4", semitones: 14, want: "Esus4"},
+		{chord: "N.C.", semitones: 5, want: "N.C."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.chord, func(t *testing.T) {
+			assert.Equal(t, tt.want, Transpose(tt.chord, tt.semitones))
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package lyrics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+var (
+	ErrInvalidLRC = errors.New("invalid lrc")
+	ErrNoTiming   = errors.New("lyrics have no timing")
+)
+
+var (
+	lineTimeRe = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	wordTimeRe = regexp.MustCompile(`<(\d+):(\d{1,2})(?:[.:](\d{1,3}))?>`)
+	idTagRe    = regexp.MustCompile(`^\[([A-Za-z#]+):(.*)\]$`)
+)
+
+// ParseLRC returns the timed lines of an LRC file. Enhanced LRC word times
+// such as <00:12.50> are kept per word. Lines without text only mark a time,
+// the times of the lines with one timestamp and of the words inside a line
+// must not decrease. A line with several timestamps, as a repeated chorus,
+// gives a timed line for each of them, put in place by time.
+func ParseLRC(data []byte) ([]domain.TimedLine, error) {
+	var (
+		lines  []domain.TimedLine
+		offset int64
+		last   int64
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(data, []byte("\uFEFF"))))
+	for n := 1; scanner.Scan(); n++ {
+		raw := strings.TrimSpace(scanner.Text())
+		if raw == "" {
+			continue
+		}
+
+		loc := lineTimeRe.FindStringSubmatchIndex(raw)
+		if loc == nil {
+			tag := idTagRe.FindStringSubmatch(raw)
+			if tag == nil {
+				return nil, fmt.Errorf("%w: line %d has no timestamp", ErrInvalidLRC, n)
+			}
+			if strings.EqualFold(tag[1], "offset") {
+				var err error
+				offset, err = strconv.ParseInt(strings.TrimSpace(tag[2]), 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("%w: line %d has a wrong offset", ErrInvalidLRC, n)
+				}
+			}
+			continue
+		}
+
+		var starts []int64
+		text := raw
+		for ; loc != nil; loc = lineTimeRe.FindStringSubmatchIndex(text) {
+			starts = append(starts, toMillis(text, loc))
+			text = text[loc[1]:]
+		}
+		if len(starts) == 1 {
+			if starts[0] < last {
+				return nil, fmt.Errorf("%w: line %d starts before the previous one", ErrInvalidLRC, n)
+			}
+			last = starts[0]
+		}
+
+		line, err := parseWords(text, starts[0])
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %w", ErrInvalidLRC, n, err)
+		}
+		if line == nil {
+			continue
+		}
+		for _, start := range starts {
+			lines = append(lines, moveLine(*line, start))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLRC, err)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: no timed lines", ErrInvalidLRC)
+	}
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].Start < lines[j].Start
+	})
+
+	// a positive offset shows the lyrics earlier
+	for i := range lines {
+		lines[i].Start = shift(lines[i].Start, offset)
+		for j := range lines[i].Words {
+			lines[i].Words[j].Start = shift(lines[i].Words[j].Start, offset)
+		}
+	}
+	return lines, nil
+}
+
+// parseWords returns the line with the word times of enhanced LRC,
+// nil when the line has no text.
+func parseWords(text string, start int64) (*domain.TimedLine, error) {
+	locs := wordTimeRe.FindAllStringSubmatchIndex(text, -1)
+	if len(locs) == 0 {
+		if strings.TrimSpace(text) == "" {
+			return nil, nil
+		}
+		return &domain.TimedLine{Start: start}, nil
+	}
+
+	line := domain.TimedLine{Start: start}
+	if word := strings.TrimSpace(text[:locs[0][0]]); word != "" {
+		line.Words = append(line.Words, domain.TimedWord{Start: start, Text: word})
+	}
+
+	last := start
+	for i, loc := range locs {
+		wordStart := toMillis(text, loc)
+		if wordStart < last {
+			return nil, errors.New("word times decrease")
+		}
+		last = wordStart
+
+		end := len(text)
+		if i+1 < len(locs) {
+			end = locs[i+1][0]
+		}
+		if word := strings.TrimSpace(text[loc[1]:end]); word != "" {
+			line.Words = append(line.Words, domain.TimedWord{Start: wordStart, Text: word})
+		}
+	}
+	if len(line.Words) == 0 {
+		return nil, nil
+	}
+	return &line, nil
+}
+
+// moveLine returns a copy of the line starting at start, its word times
+// are moved by the same amount.
+func moveLine(line domain.TimedLine, start int64) domain.TimedLine {
+	moved := domain.TimedLine{Start: start}
+	for _, word := range line.Words {
+		word.Start += start - line.Start
+		moved.Words = append(moved.Words, word)
+	}
+	return moved
+}
+
+// toMillis converts the minutes, seconds and fraction groups of the match.
+func toMillis(s string, loc []int) int64 {
+	minutes, _ := strconv.ParseInt(s[loc[2]:loc[3]], 10, 64)
+	seconds, _ := strconv.ParseInt(s[loc[4]:loc[5]], 10, 64)
+
+	var millis int64
+	if loc[6] >= 0 {
+		fraction := s[loc[6]:loc[7]]
+		millis, _ = strconv.ParseInt((fraction + "00")[:3], 10, 64)
+	}
+	return (minutes*60+seconds)*1000 + millis
+}
+
+func shift(start, offset int64) int64 {
+	return max(start-offset, 0)
+}
+
+// FormatLRC returns the timed lyrics as an LRC file, with enhanced
+// the lines which have word times carry them.
+func FormatLRC(artist, title string, text domain.SongText, enhanced bool) (string, error) {
+	if !text.HasTiming() {
+		return "", ErrNoTiming
+	}
+
+	var b strings.Builder
+	b.WriteString("[ar:" + artist + "]\n")
+	b.WriteString("[ti:" + title + "]\n")
+	for _, item := range text {
+		for i, line := range item.Lines() {
+			timing := item.Timing[i]
+			b.WriteString("[" + formatTime(timing.Start) + "]")
+			if !enhanced || len(timing.Words) == 0 {
+				b.WriteString(line + "\n")
+				continue
+			}
+			for j, word := range timing.Words {
+				if j > 0 {
+					b.WriteString(" ")
+				}
+				b.WriteString("<" + formatTime(word.Start) + ">" + word.Text)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// formatTime returns mm:ss.xx, minutes may exceed 59.
+func formatTime(millis int64) string {
+	return fmt.Sprintf("%02d:%02d.%02d", millis/60000, millis/1000%60, millis%1000/10)
+}
@@ -0,0 +1,131 @@
+package lyrics
+
+import (
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLRC(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    []domain.TimedLine
+		wantErr bool
+	}{
+		{
+			name: "simple",
+			data: "\uFEFF[ar:Lady Gaga]\n[ti:Poker Face]\n\n[00:12.00]I wanna hold 'em\n[00:15.5]Fold 'em\n[01:02]Can't read my\n",
+			want: []domain.TimedLine{{Start: 12000}, {Start: 15500}, {Start: 62000}},
+		},
+		{
+			name: "lines without text only mark a time",
+			data: "[00:01.00]\n[00:02.00]one\n[00:03.00]  \n[00:04.00]two",
+			want: []domain.TimedLine{{Start: 2000}, {Start: 4000}},
+		},
+		{
+			name: "enhanced",
+			data: "[00:12.00]<00:12.00>Can't <00:12.40>read <00:12.85>my<00:13.20>",
+			want: []domain.TimedLine{{Start: 12000, Words: []domain.TimedWord{
+				{Start: 12000, Text: "Can't"},
+				{Start: 12400, Text: "read"},
+				{Start: 12850, Text: "my"},
+			}}},
+		},
+		{
+			name: "offset",
+			data: "[offset:+500]\n[00:00.20]one\n[00:12.00]<00:12.00>two <00:12.60>three",
+			want: []domain.TimedLine{{Start: 0}, {Start: 11500, Words: []domain.TimedWord{
+				{Start: 11500, Text: "two"},
+				{Start: 12100, Text: "three"},
+			}}},
+		},
+		{
+			name:    "line without timestamp",
+			data:    "[00:01.00]one\ntwo",
+			wantErr: true,
+		},
+		{
+			name: "several timestamps",
+			data: "[00:01.00]one\n[00:02.00][00:04.00]chorus\n[00:03.00]two",
+			want: []domain.TimedLine{{Start: 1000}, {Start: 2000}, {Start: 3000}, {Start: 4000}},
+		},
+		{
+			name: "several timestamps enhanced",
+			data: "[00:10.00][00:30.00]<00:10.00>la <00:10.50>la",
+			want: []domain.TimedLine{
+				{Start: 10000, Words: []domain.TimedWord{{Start: 10000, Text: "la"}, {Start: 10500, Text: "la"}}},
+				{Start: 30000, Words: []domain.TimedWord{{Start: 30000, Text: "la"}, {Start: 30500, Text: "la"}}},
+			},
+		},
+		{
+			name:    "times decrease",
+			data:    "[00:02.00]one\n[00:01.00]two",
+			wantErr: true,
+		},
+		{
+			name:    "times decrease after several timestamps",
+			data:    "[00:01.00][00:05.00]chorus\n[00:03.00]one\n[00:02.00]two",
+			wantErr: true,
+		},
+		{
+			name:    "word times decrease",
+			data:    "[00:02.00]<00:02.50>one <00:02.10>two",
+			wantErr: true,
+		},
+		{
+			name:    "wrong offset",
+			data:    "[offset:soon]\n[00:01.00]one",
+			wantErr: true,
+		},
+		{
+			name:    "no timed lines",
+			data:    "[ar:Lady Gaga]",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLRC([]byte(tt.data))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLRC)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFormatLRC(t *testing.T) {
+	text := domain.SongText{
+		{Type: domain.Verse, Text: "I wanna hold 'em\n\nFold 'em"},
+		{Type: domain.Chorus, Text: "Can't read my"},
+	}
+	timing := []domain.TimedLine{
+		{Start: 12000},
+		{Start: 15500},
+		{Start: 62000, Words: []domain.TimedWord{{Start: 62000, Text: "Can't"}, {Start: 62400, Text: "read"}, {Start: 62850, Text: "my"}}},
+	}
+
+	_, err := FormatLRC("Lady Gaga", "Poker Face", text, false)
+	assert.Equal(t, ErrNoTiming, err)
+
+	assert.ErrorIs(t, text.SetTiming(timing[1:]), domain.ErrTimingMismatch)
+	assert.NoError(t, text.SetTiming(timing))
+
+	got, err := FormatLRC("Lady Gaga", "Poker Face", text, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "[ar:Lady Gaga]\n[ti:Poker Face]\n"+
+		"[00:12.00]I wanna hold 'em\n[00:15.50]Fold 'em\n[01:02.00]Can't read my\n", got)
+
+	got, err = FormatLRC("Lady Gaga", "Poker Face", text, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "[ar:Lady Gaga]\n[ti:Poker Face]\n"+
+		"[00:12.00]I wanna hold 'em\n[00:15.50]Fold 'em\n[01:02.00]<01:02.00>Can't <01:02.40>read <01:02.85>my\n", got)
+
+	parsed, err := ParseLRC([]byte(got))
+	assert.NoError(t, err)
+	assert.Equal(t, timing, parsed)
+}
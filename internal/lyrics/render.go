@@ -0,0 +1,212 @@
+// Package lyrics renders song lyrics for reading and printing.
+package lyrics
+
+import (
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+var ErrUnknownFormat = errors.New("unknown lyrics format")
+
+// Format is the output format of the rendered lyrics.
+type Format string
+
+const (
+	Plain    Format = "plain"
+	Markdown Format = "markdown"
+	HTML     Format = "html"
+)
+
+// ParseFormat returns the format by its name, the empty name is Plain.
+func ParseFormat(name string) (Format, error) {
+	switch f := Format(strings.ToLower(name)); f {
+	case "":
+		return Plain, nil
+	case Plain, Markdown, HTML:
+		return f, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// ContentType returns the media type of the rendered lyrics.
+func (f Format) ContentType() string {
+	switch f {
+	case Markdown:
+		return "text/markdown; charset=utf-8"
+	case HTML:
+		return "text/html; charset=utf-8"
+	default:
+		return "text/plain; charset=utf-8"
+	}
+}
+
+// Options -.
+type Options struct {
+	Format Format
+	// Headers puts the label of every section above it.
+	Headers bool
+	// Collapse replaces a chorus repeating an earlier one with a reference
+	// such as "[Chorus]".
+	Collapse bool
+}
+
+var sectionNames = map[domain.TypeSongItem]string{
+	domain.Intro:     "Intro",
+	domain.Verse:     "Verse",
+	domain.PreChorus: "Pre-Chorus",
+	domain.Chorus:    "Chorus",
+	domain.Refrain:   "Refrain",
+	domain.Hook:      "Hook",
+	domain.Bridge:    "Bridge",
+	domain.Outro:     "Outro",
+}
+
+// collapsible are the section types which are collapsed when repeated.
+var collapsible = map[domain.TypeSongItem]bool{
+	domain.PreChorus: true,
+	domain.Chorus:    true,
+	domain.Refrain:   true,
+	domain.Hook:      true,
+}
+
+// section is a section of the lyrics prepared for rendering.
+type section struct {
+	Type   domain.TypeSongItem
+	Header string
+	Lines  []string
+	// Repeat is set when the section is collapsed into a reference.
+	Repeat bool
+}
+
+// Render returns the whole lyrics in performance order.
+func Render(text domain.SongText, opts Options) string {
+	sections := prepare(text, opts.Collapse)
+
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		switch opts.Format {
+		case Markdown:
+			writeMarkdown(&b, s, opts.Headers)
+		case HTML:
+			writeHTML(&b, s, opts.Headers)
+		default:
+			writePlain(&b, s, opts.Headers)
+		}
+	}
+	return b.String()
+}
+
+func prepare(text domain.SongText, collapse bool) []section {
+	sections := make([]section, 0, len(text))
+	type repeat struct {
+		Type domain.TypeSongItem
+		Text string
+	}
+	seen := make(map[repeat]bool, len(text))
+	verses := 0
+	for _, item := range text {
+		s := section{
+			Type:   item.Type,
+			Header: item.Label,
+			Lines:  strings.Split(strings.TrimSpace(item.Text), "\n"),
+		}
+		if item.Type == domain.Verse {
+			verses++
+		}
+		if s.Header == "" {
+			s.Header = sectionName(item.Type)
+			if item.Type == domain.Verse {
+				s.Header += " " + strconv.Itoa(verses)
+			}
+		}
+
+		key := repeat{Type: item.Type, Text: strings.TrimSpace(item.Text)}
+		s.Repeat = collapse && collapsible[item.Type] && seen[key]
+		seen[key] = true
+
+		sections = append(sections, s)
+	}
+	return sections
+}
+
+func sectionName(t domain.TypeSongItem) string {
+	if name, ok := sectionNames[t]; ok {
+		return name
+	}
+	return string(t)
+}
+
+func writePlain(b *strings.Builder, s section, headers bool) {
+	if s.Repeat {
+		b.WriteString("[" + s.Header + "]\n")
+		return
+	}
+	if headers {
+		b.WriteString("[" + s.Header + "]\n")
+	}
+	for _, line := range s.Lines {
+		b.WriteString(line + "\n")
+	}
+}
+
+func writeMarkdown(b *strings.Builder, s section, headers bool) {
+	if s.Repeat {
+		b.WriteString("*[" + escapeMarkdown(s.Header) + "]*\n")
+		return
+	}
+	if headers {
+		b.WriteString("**" + escapeMarkdown(s.Header) + "**\n\n")
+	}
+	for i, line := range s.Lines {
+		b.WriteString(escapeMarkdown(line))
+		if i < len(s.Lines)-1 {
+			// a trailing backslash is a hard line break
+			b.WriteString("\\")
+		}
+		b.WriteString("\n")
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"#", `\#`,
+	"<", `\<`,
+	">", `\>`,
+)
+
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func writeHTML(b *strings.Builder, s section, headers bool) {
+	b.WriteString(`<section class="` + html.EscapeString(string(s.Type)) + `">` + "\n")
+	if s.Repeat {
+		b.WriteString(`<p class="repeat">[` + html.EscapeString(s.Header) + "]</p>\n")
+		b.WriteString("</section>\n")
+		return
+	}
+	if headers {
+		b.WriteString("<h3>" + html.EscapeString(s.Header) + "</h3>\n")
+	}
+	b.WriteString("<p>")
+	for i, line := range s.Lines {
+		if i > 0 {
+			b.WriteString("<br>\n")
+		}
+		b.WriteString(html.EscapeString(line))
+	}
+	b.WriteString("</p>\n")
+	b.WriteString("</section>\n")
+}
@@ -0,0 +1,102 @@
+package lyrics
+
+import (
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+var pokerFace = domain.SongText{
+	{Type: domain.Verse, Text: "I wanna hold 'em like they do in Texas, please\nFold 'em, let 'em hit me, raise it"},
+	{Type: domain.Chorus, Text: "Can't read my, can't read my\nNo, he can't read my poker face"},
+	{Type: domain.Verse, Label: "Verse 2 <live>", Text: "I wanna roll with him"},
+	{Type: domain.Chorus, Text: "Can't read my, can't read my\nNo, he can't read my poker face"},
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Format
+		wantErr error
+	}{
+		{name: "", want: Plain},
+		{name: "plain", want: Plain},
+		{name: "Markdown", want: Markdown},
+		{name: "html", want: HTML},
+		{name: "pdf", wantErr: ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseFormat(tt.name)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name string
+		text domain.SongText
+		opts Options
+		want string
+	}{
+		{
+			name: "plain without headers",
+			text: pokerFace[:2],
+			opts: Options{Format: Plain},
+			want: "I wanna hold 'em like they do in Texas, please\nFold 'em, let 'em hit me, raise it\n" +
+				"\n" +
+				"Can't read my, can't read my\nNo, he can't read my poker face\n",
+		},
+		{
+			name: "plain with headers and collapsed chorus",
+			text: pokerFace,
+			opts: Options{Format: Plain, Headers: true, Collapse: true},
+			want: "[Verse 1]\nI wanna hold 'em like they do in Texas, please\nFold 'em, let 'em hit me, raise it\n" +
+				"\n" +
+				"[Chorus]\nCan't read my, can't read my\nNo, he can't read my poker face\n" +
+				"\n" +
+				"[Verse 2 <live>]\nI wanna roll with him\n" +
+				"\n" +
+				"[Chorus]\n",
+		},
+		{
+			name: "markdown",
+			text: domain.SongText{
+				{Type: domain.Intro, Text: "Mum-mum-mum-mah"},
+				{Type: domain.Chorus, Label: "Chorus (x2)", Text: "Can't read my *poker* face\nShe's got to love nobody"},
+				{Type: domain.Chorus, Label: "Chorus (x2)", Text: "Can't read my *poker* face\nShe's got to love nobody"},
+			},
+			opts: Options{Format: Markdown, Headers: true, Collapse: true},
+			want: "**Intro**\n\nMum-mum-mum-mah\n" +
+				"\n" +
+				"**Chorus (x2)**\n\nCan't read my \\*poker\\* face\\\nShe's got to love nobody\n" +
+				"\n" +
+				"*[Chorus (x2)]*\n",
+		},
+		{
+			name: "html",
+			text: pokerFace[2:],
+			opts: Options{Format: HTML, Headers: true, Collapse: true},
+			want: "<section class=\"verse\">\n<h3>Verse 2 &lt;live&gt;</h3>\n<p>I wanna roll with him</p>\n</section>\n" +
+				"\n" +
+				"<section class=\"chorus\">\n<h3>Chorus</h3>\n<p>Can&#39;t read my, can&#39;t read my<br>\nNo, he can&#39;t read my poker face</p>\n</section>\n",
+		},
+		{
+			name: "verses are not collapsed",
+			text: domain.SongText{
+				{Type: domain.Verse, Text: "la"},
+				{Type: domain.Verse, Text: "la"},
+			},
+			opts: Options{Format: Plain, Headers: true, Collapse: true},
+			want: "[Verse 1]\nla\n\n[Verse 2]\nla\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Render(tt.text, tt.opts))
+		})
+	}
+}
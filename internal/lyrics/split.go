@@ -0,0 +1,177 @@
+package lyrics
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+// maxHeaderLength is the longest line taken for a section header.
+const maxHeaderLength = 100
+
+// headerRe matches the name of a header such as "verse 2" or "chorus (x2)".
+var headerRe = regexp.MustCompile(`^(intro|verse|pre-?chorus|pre chorus|chorus|refrain|hook|bridge|outro)(\s*\d+)?(\s*(\(.*\)|x\d+))?$`)
+
+// Split proposes the sections of raw lyrics. Sections are separated by blank
+// lines or start with a header such as "[Chorus]", "Verse 2:" or
+// "[Verse 1: Lady Gaga]". A header alone repeats the last section of its
+// type. Sections without a header are choruses when their text repeats and
+// verses otherwise.
+func Split(text string) domain.SongText {
+	var (
+		sections []block
+		current  *block
+	)
+	end := func() {
+		if current != nil {
+			sections = append(sections, *current)
+			current = nil
+		}
+	}
+
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	for _, line := range strings.Split(strings.TrimPrefix(text, "\uFEFF"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			end()
+			continue
+		}
+		if typ, label, ok := parseHeader(line); ok {
+			end()
+			current = &block{typed: true, item: domain.SongItem{Type: typ, Label: label}}
+			continue
+		}
+		if current == nil {
+			current = &block{}
+		}
+		current.lines = append(current.lines, line)
+	}
+	end()
+
+	return markSections(attachHeaders(sections))
+}
+
+// block is a section of the raw lyrics, typed when it has a header.
+type block struct {
+	item  domain.SongItem
+	lines []string
+	typed bool
+}
+
+// attachHeaders gives a header separated from its lyrics by a blank line
+// to the next block, unless the header repeats an earlier section.
+func attachHeaders(blocks []block) []block {
+	result := make([]block, 0, len(blocks))
+	seen := make(map[domain.TypeSongItem]bool)
+	for i := 0; i < len(blocks); i++ {
+		b := blocks[i]
+		if b.typed && len(b.lines) == 0 && !seen[b.item.Type] &&
+			i+1 < len(blocks) && !blocks[i+1].typed {
+			b.lines = blocks[i+1].lines
+			i++
+		}
+		if b.typed {
+			seen[b.item.Type] = true
+		}
+		result = append(result, b)
+	}
+	return result
+}
+
+func markSections(blocks []block) domain.SongText {
+	typed := make(map[string]domain.SongItem)
+	counts := make(map[string]int)
+	for _, b := range blocks {
+		key := normalizeBlock(b.lines)
+		if key == "" {
+			continue
+		}
+		counts[key]++
+		if _, ok := typed[key]; b.typed && !ok {
+			typed[key] = b.item
+		}
+	}
+
+	text := make(domain.SongText, 0, len(blocks))
+	for _, b := range blocks {
+		item := b.item
+		item.Text = strings.Join(b.lines, "\n")
+		key := normalizeBlock(b.lines)
+
+		switch {
+		case key == "":
+			if repeat, ok := lastSection(text, item); ok {
+				text = append(text, repeat)
+			}
+			continue
+		case b.typed:
+		case typed[key].Type != "":
+			item.Type, item.Label = typed[key].Type, typed[key].Label
+		case counts[key] > 1:
+			item.Type = domain.Chorus
+		default:
+			item.Type = domain.Verse
+		}
+		text = append(text, item)
+	}
+	return text
+}
+
+// lastSection returns the last section of the type, with the label when the
+// header of the repeat has one.
+func lastSection(text domain.SongText, header domain.SongItem) (domain.SongItem, bool) {
+	for i := len(text) - 1; i >= 0; i-- {
+		if text[i].Type != header.Type {
+			continue
+		}
+		if header.Label == "" || strings.EqualFold(text[i].Label, header.Label) {
+			return text[i], true
+		}
+	}
+	return domain.SongItem{}, false
+}
+
+// parseHeader recognizes a line such as "[Chorus]", "(Pre-Chorus)", "Verse 2:"
+// or "[Verse 1: Lady Gaga]". The label is dropped when it is just the name
+// of the type.
+func parseHeader(line string) (domain.TypeSongItem, string, bool) {
+	if utf8.RuneCountInString(line) > maxHeaderLength {
+		return "", "", false
+	}
+
+	var label string
+	switch {
+	case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"),
+		strings.HasPrefix(line, "(") && strings.HasSuffix(line, ")"):
+		label = line[1 : len(line)-1]
+	case strings.HasSuffix(line, ":"):
+		label = strings.TrimSuffix(line, ":")
+	default:
+		return "", "", false
+	}
+	label = strings.TrimSpace(label)
+
+	// the performers follow the colon in "[Verse 1: Lady Gaga]"
+	name, _, _ := strings.Cut(strings.ToLower(label), ":")
+	m := headerRe.FindStringSubmatch(strings.TrimSpace(name))
+	if m == nil {
+		return "", "", false
+	}
+
+	typ := domain.TypeSongItem(m[1])
+	if strings.HasPrefix(m[1], "pre") {
+		typ = domain.PreChorus
+	}
+	if strings.EqualFold(label, m[1]) {
+		label = ""
+	}
+	return typ, label, true
+}
+
+// normalizeBlock returns the text of the block for comparing repeats,
+// ignoring case and spacing.
+func normalizeBlock(lines []string) string {
+	return strings.Join(strings.Fields(strings.ToLower(strings.Join(lines, "\n"))), " ")
+}
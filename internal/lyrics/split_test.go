@@ -0,0 +1,65 @@
+package lyrics
+
+import (
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want domain.SongText
+	}{
+		{
+			name: "empty",
+			text: " \n\n",
+			want: domain.SongText{},
+		},
+		{
+			name: "repeated blocks are choruses",
+			text: "I wanna hold 'em like they do in Texas, please\r\nFold 'em, let 'em hit me\r\n\r\n" +
+				"Can't read my, can't read my\nNo, he can't read my poker face\n\n\n" +
+				"I wanna roll with him\n\n" +
+				"can't read my,  can't read my\nNo, he can't read my poker face  \n",
+			want: domain.SongText{
+				{Type: domain.Verse, Text: "I wanna hold 'em like they do in Texas, please\nFold 'em, let 'em hit me"},
+				{Type: domain.Chorus, Text: "Can't read my, can't read my\nNo, he can't read my poker face"},
+				{Type: domain.Verse, Text: "I wanna roll with him"},
+				{Type: domain.Chorus, Text: "can't read my,  can't read my\nNo, he can't read my poker face"},
+			},
+		},
+		{
+			name: "headers",
+			text: "[Intro]\nMum-mum-mum-mah\n[Verse 1: Lady Gaga]\nI wanna hold 'em\n\n" +
+				"Pre-Chorus:\nI won't tell you\n\n" +
+				"(Chorus x2)\n\nCan't read my\n\n" +
+				"Hook me up:\nwith the verse\n\n" +
+				"[Chorus x2]\n\n" +
+				"Can't read my\n",
+			want: domain.SongText{
+				{Type: domain.Intro, Text: "Mum-mum-mum-mah"},
+				{Type: domain.Verse, Label: "Verse 1: Lady Gaga", Text: "I wanna hold 'em"},
+				{Type: domain.PreChorus, Text: "I won't tell you"},
+				{Type: domain.Chorus, Label: "Chorus x2", Text: "Can't read my"},
+				{Type: domain.Verse, Text: "Hook me up:\nwith the verse"},
+				{Type: domain.Chorus, Label: "Chorus x2", Text: "Can't read my"},
+				{Type: domain.Chorus, Label: "Chorus x2", Text: "Can't read my"},
+			},
+		},
+		{
+			name: "header without an earlier section",
+			text: "[Bridge]\n\n[Verse]\nla",
+			want: domain.SongText{
+				{Type: domain.Verse, Text: "la"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Split(tt.text))
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package patch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "replace member",
+			doc:   `{"a":"b"}`,
+			patch: `{"a":"c"}`,
+			want:  `{"a":"c"}`,
+		},
+		{
+			name:  "add member",
+			doc:   `{"a":"b"}`,
+			patch: `{"b":"c"}`,
+			want:  `{"a":"b","b":"c"}`,
+		},
+		{
+			name:  "null removes member",
+			doc:   `{"a":"b","b":"c"}`,
+			patch: `{"a":null}`,
+			want:  `{"b":"c"}`,
+		},
+		{
+			name:  "arrays are replaced",
+			doc:   `{"a":[{"b":"c"}]}`,
+			patch: `{"a":[1]}`,
+			want:  `{"a":[1]}`,
+		},
+		{
+			name:  "nested objects are merged",
+			doc:   `{"a":{"b":"c","d":"e"}}`,
+			patch: `{"a":{"d":null,"f":1}}`,
+			want:  `{"a":{"b":"c","f":1}}`,
+		},
+		{
+			name:    "malformed patch",
+			doc:     `{"a":"b"}`,
+			patch:   `{"a":`,
+			wantErr: ErrInvalidPatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MergePatch([]byte(tt.doc), []byte(tt.patch))
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.JSONEq(t, tt.want, string(got))
+			}
+		})
+	}
+}
+
+func TestJSONPatch(t *testing.T) {
+	doc := `{"name":"Poker Face","text":[{"type":"verse","text":"one"},{"type":"chorus","text":"two"}]}`
+
+	tests := []struct {
+		name    string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			name:  "replace text of a section",
+			patch: `[{"op":"replace","path":"/text/1/text","value":"three"}]`,
+			want:  `{"name":"Poker Face","text":[{"type":"verse","text":"one"},{"type":"chorus","text":"three"}]}`,
+		},
+		{
+			name:  "insert section",
+			patch: `[{"op":"add","path":"/text/1","value":{"type":"verse","text":"new"}}]`,
+			want:  `{"name":"Poker Face","text":[{"type":"verse","text":"one"},{"type":"verse","text":"new"},{"type":"chorus","text":"two"}]}`,
+		},
+		{
+			name:  "append section",
+			patch: `[{"op":"add","path":"/text/-","value":{"type":"verse","text":"last"}}]`,
+			want:  `{"name":"Poker Face","text":[{"type":"verse","text":"one"},{"type":"chorus","text":"two"},{"type":"verse","text":"last"}]}`,
+		},
+		{
+			name:  "remove section",
+			patch: `[{"op":"remove","path":"/text/0"}]`,
+			want:  `{"name":"Poker Face","text":[{"type":"chorus","text":"two"}]}`,
+		},
+		{
+			name:  "move section",
+			patch: `[{"op":"move","from":"/text/1","path":"/text/0"}]`,
+			want:  `{"name":"Poker Face","text":[{"type":"chorus","text":"two"},{"type":"verse","text":"one"}]}`,
+		},
+		{
+			name:  "copy section",
+			patch: `[{"op":"copy","from":"/text/1","path":"/text/-"}]`,
+			want:  `{"name":"Poker Face","text":[{"type":"verse","text":"one"},{"type":"chorus","text":"two"},{"type":"chorus","text":"two"}]}`,
+		},
+		{
+			name:  "test before replace",
+			patch: `[{"op":"test","path":"/name","value":"Poker Face"},{"op":"replace","path":"/name","value":"Alejandro"}]`,
+			want:  `{"name":"Alejandro","text":[{"type":"verse","text":"one"},{"type":"chorus","text":"two"}]}`,
+		},
+		{
+			name:  "escaped pointer",
+			patch: `[{"op":"add","path":"/a~1b~0c","value":1},{"op":"remove","path":"/text"}]`,
+			want:  `{"name":"Poker Face","a/b~c":1}`,
+		},
+		{
+			name:    "test failed",
+			patch:   `[{"op":"test","path":"/name","value":"Alejandro"}]`,
+			wantErr: ErrTestFailed,
+		},
+		{
+			name:    "replace of a missing member",
+			patch:   `[{"op":"replace","path":"/link","value":"x"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "index out of range",
+			patch:   `[{"op":"remove","path":"/text/2"}]`,
+			wantErr: ErrPathNotFound,
+		},
+		{
+			name:    "leading zero index",
+			patch:   `[{"op":"remove","path":"/text/01"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "unknown operation",
+			patch:   `[{"op":"rename","path":"/name"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "add without value",
+			patch:   `[{"op":"add","path":"/link"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "move into itself",
+			patch:   `[{"op":"move","from":"/text","path":"/text/0"}]`,
+			wantErr: ErrInvalidPatch,
+		},
+		{
+			name:    "patch is not an array",
+			patch:   `{"op":"remove","path":"/name"}`,
+			wantErr: ErrInvalidPatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := JSONPatch([]byte(doc), []byte(tt.patch))
+			assert.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.JSONEq(t, tt.want, string(got))
+			}
+		})
+	}
+}
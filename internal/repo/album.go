@@ -0,0 +1,325 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) CreateAlbum(ctx context.Context, album *domain.Album) (*uuid.UUID, error) {
+	now := time.Now()
+	query, args, err := r.pg.Builder.
+		Insert(tableAlbum).
+		Columns(
+			"title",
+			"artist_id",
+			"release_date",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			album.Title,
+			album.ArtistID,
+			album.ReleaseDate,
+			now,
+			now,
+		).
+		Suffix(suffixReturningID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	var id uuid.UUID
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		return nil, fmt.Errorf("error create album: %w", err)
+	}
+
+	return &id, nil
+}
+
+// UpdateAlbum updates the fields set in the patch, the artist by its ID.
+func (r *Repository) UpdateAlbum(ctx context.Context, patch *domain.AlbumPatch) error {
+	fields := map[string]any{"updated_at": time.Now()}
+	if patch.Title != nil {
+		fields["title"] = *patch.Title
+	}
+	if patch.ArtistID != nil {
+		fields["artist_id"] = *patch.ArtistID
+	}
+	if patch.ReleaseDate != nil {
+		fields["release_date"] = *patch.ReleaseDate
+	}
+
+	query, args, err := r.pg.Builder.
+		Update(tableAlbum).
+		SetMap(fields).
+		Where(squirrel.Eq{"id": patch.ID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error update album: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrAlbumNotFound
+	}
+	return nil
+}
+
+func (r *Repository) DeleteAlbum(ctx context.Context, id *uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Delete(tableAlbum).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error delete album: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrAlbumNotFound
+	}
+	return nil
+}
+
+// GetAlbum returns the album with its tracks in order.
+func (r *Repository) GetAlbum(ctx context.Context, id *uuid.UUID) (*domain.Album, error) {
+	query, args, err := r.albumSelect().
+		Where(squirrel.Eq{"al.id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	album, err := scanAlbum(r.conn(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrAlbumNotFound
+		}
+		return nil, fmt.Errorf("error get album: %w", err)
+	}
+
+	query, args, err = r.pg.Builder.Select(
+		"t.position",
+		"t.song_id",
+		"s.name",
+	).From(tableAlbumTrack + " t").
+		Join(tableSong + " s ON s.id = t.song_id").
+		Where(squirrel.Eq{"t.album_id": id, "s.deleted_at": nil}).
+		OrderBy("t.position").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error get album tracks: %w", err)
+	}
+
+	defer rows.Close()
+
+	album.Tracks = make([]domain.AlbumTrack, 0)
+	for rows.Next() {
+		var t domain.AlbumTrack
+		err := rows.Scan(&t.Position, &t.SongID, &t.Name)
+		if err != nil {
+			return nil, err
+		}
+		album.Tracks = append(album.Tracks, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return album, nil
+}
+
+func (r *Repository) GetAlbums(ctx context.Context, filter *domain.AlbumRequest) ([]domain.Album, error) {
+	where := squirrel.And{}
+	if filter.Title != "" {
+		where = append(where, squirrel.Expr("normalize_name(al.title) LIKE '%' || normalize_name(?) || '%'", filter.Title))
+	}
+	if filter.ArtistID != uuid.Nil {
+		where = append(where, squirrel.Eq{"al.artist_id": filter.ArtistID})
+	}
+
+	query, args, err := r.albumSelect().
+		Where(where).
+		OrderBy("al.release_date", "al.id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	albums := make([]domain.Album, 0, filter.Limit)
+	for rows.Next() {
+		album, err := scanAlbum(rows)
+		if err != nil {
+			return nil, err
+		}
+		albums = append(albums, *album)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return albums, nil
+}
+
+// SetAlbumTracks replaces the track list, songs take positions in the given order.
+// The album row is locked as in AddAlbumTrack.
+func (r *Repository) SetAlbumTracks(ctx context.Context, albumID uuid.UUID, songIDs []uuid.UUID) error {
+	return r.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.lockAlbum(ctx, albumID)
+		if err != nil {
+			return err
+		}
+
+		query, args, err := r.pg.Builder.
+			Delete(tableAlbumTrack).
+			Where(squirrel.Eq{"album_id": albumID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error build query: %w", err)
+		}
+
+		_, err = r.conn(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("error delete album tracks: %w", err)
+		}
+
+		if len(songIDs) == 0 {
+			return nil
+		}
+
+		builder := r.pg.Builder.
+			Insert(tableAlbumTrack).
+			Columns("album_id", "song_id", "position")
+		for i, id := range songIDs {
+			builder = builder.Values(albumID, id, i+1)
+		}
+
+		query, args, err = builder.ToSql()
+		if err != nil {
+			return fmt.Errorf("error build query: %w", err)
+		}
+
+		_, err = r.conn(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			if isPgError(err, pgForeignKeyViolation) {
+				return ErrSongNotFound
+			}
+			return fmt.Errorf("error insert album tracks: %w", err)
+		}
+		return nil
+	})
+}
+
+// AddAlbumTrack appends the song to the end of the album. The album row is
+// locked first, so that concurrent appends don't take the same position.
+func (r *Repository) AddAlbumTrack(ctx context.Context, albumID, songID uuid.UUID) error {
+	return r.ExecTx(ctx, func(ctx context.Context) error {
+		err := r.lockAlbum(ctx, albumID)
+		if err != nil {
+			return err
+		}
+
+		query, args, err := r.pg.Builder.
+			Insert(tableAlbumTrack).
+			Columns("album_id", "song_id", "position").
+			Select(squirrel.Select().
+				Column("?::uuid", albumID).
+				Column("?::uuid", songID).
+				Column("COALESCE(MAX(position), 0) + 1").
+				From(tableAlbumTrack).
+				Where(squirrel.Eq{"album_id": albumID})).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error build query: %w", err)
+		}
+
+		_, err = r.conn(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("error add album track: %w", err)
+		}
+		return nil
+	})
+}
+
+// lockAlbum locks the album row until the end of the transaction.
+func (r *Repository) lockAlbum(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Select("id").
+		From(tableAlbum).
+		Where(squirrel.Eq{"id": id}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrAlbumNotFound
+		}
+		return fmt.Errorf("error lock album: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) albumSelect() squirrel.SelectBuilder {
+	return r.pg.Builder.Select(
+		"al.id",
+		"al.title",
+		"al.artist_id",
+		"ar.name",
+		"al.release_date",
+		"al.created_at",
+		"al.updated_at",
+	).From(tableAlbum + " al").
+		Join(tableArtist + " ar ON ar.id = al.artist_id")
+}
+
+func scanAlbum(row pgx.Row) (*domain.Album, error) {
+	var album domain.Album
+	err := row.Scan(
+		&album.ID,
+		&album.Title,
+		&album.ArtistID,
+		&album.Artist,
+		&album.ReleaseDate,
+		&album.CreatedAt,
+		&album.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &album, nil
+}
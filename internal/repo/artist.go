@@ -0,0 +1,309 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) CreateArtist(ctx context.Context, artist *domain.Artist) (*uuid.UUID, error) {
+	now := time.Now()
+	query, args, err := r.pg.Builder.
+		Insert(tableArtist).
+		Columns(
+			"name",
+			"description",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			artist.Name,
+			artist.Description,
+			now,
+			now,
+		).
+		Suffix(suffixReturningID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	var id uuid.UUID
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&id)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return nil, ErrArtistExists
+		}
+		return nil, fmt.Errorf("error create artist: %w", err)
+	}
+
+	return &id, nil
+}
+
+// UpsertArtist returns the artist with the same normalized name
+// or creates a new one.
+func (r *Repository) UpsertArtist(ctx context.Context, name string) (*domain.Artist, error) {
+	now := time.Now()
+	query, args, err := r.pg.Builder.
+		Insert(tableArtist).
+		Columns(
+			"name",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			name,
+			now,
+			now,
+		).
+		Suffix("ON CONFLICT (normalize_name(name)) DO UPDATE SET name = " + tableArtist + ".name").
+		Suffix("RETURNING id, name, description, created_at, updated_at").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	var artist domain.Artist
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(
+		&artist.ID,
+		&artist.Name,
+		&artist.Description,
+		&artist.CreatedAt,
+		&artist.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error upsert artist: %w", err)
+	}
+
+	return &artist, nil
+}
+
+// UpdateArtist updates the fields set in the patch and the denormalized
+// executor of the songs when the name changes, the renamed songs get
+// a new version and a revision.
+func (r *Repository) UpdateArtist(ctx context.Context, patch *domain.ArtistPatch) error {
+	return r.ExecTx(ctx, func(ctx context.Context) error {
+		fields := map[string]any{"updated_at": time.Now()}
+		if patch.Name != nil {
+			fields["name"] = *patch.Name
+		}
+		if patch.Description != nil {
+			fields["description"] = *patch.Description
+		}
+
+		query, args, err := r.pg.Builder.
+			Update(tableArtist).
+			SetMap(fields).
+			Where(squirrel.Eq{"id": patch.ID}).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error build query: %w", err)
+		}
+
+		commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+		if err != nil {
+			if isPgError(err, pgUniqueViolation) {
+				return ErrArtistExists
+			}
+			return fmt.Errorf("error update artist: %w", err)
+		}
+		if commandTag.RowsAffected() == 0 {
+			return ErrArtistNotFound
+		}
+		if patch.Name == nil {
+			return nil
+		}
+
+		// the renamed songs get a new version and revision as after a patch
+		query, args, err = r.pg.Builder.
+			Update(tableSong).
+			SetMap(map[string]any{
+				"executor":   *patch.Name,
+				"updated_at": time.Now(),
+				"version":    squirrel.Expr("version + 1"),
+			}).
+			Where(squirrel.Eq{"artist_id": patch.ID}).
+			Where(squirrel.NotEq{"executor": *patch.Name}).
+			Suffix(suffixReturningID).
+			ToSql()
+		if err != nil {
+			return fmt.Errorf("error build query: %w", err)
+		}
+
+		rows, err := r.conn(ctx).Query(ctx, query, args...)
+		if err != nil {
+			return fmt.Errorf("error update executor: %w", err)
+		}
+		defer rows.Close()
+
+		var songIDs []uuid.UUID
+		for rows.Next() {
+			var id uuid.UUID
+			err = rows.Scan(&id)
+			if err != nil {
+				return fmt.Errorf("error scan song id: %w", err)
+			}
+			songIDs = append(songIDs, id)
+		}
+		if err = rows.Err(); err != nil {
+			return fmt.Errorf("error update executor: %w", err)
+		}
+		rows.Close()
+
+		return r.AddRevisions(ctx, songIDs)
+	})
+}
+
+func (r *Repository) DeleteArtist(ctx context.Context, id *uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Delete(tableArtist).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return ErrArtistHasSongs
+		}
+		return fmt.Errorf("error delete artist: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrArtistNotFound
+	}
+	return nil
+}
+
+func (r *Repository) GetArtist(ctx context.Context, id *uuid.UUID) (*domain.Artist, error) {
+	query, args, err := r.pg.Builder.Select(
+		"id",
+		"name",
+		"description",
+		"created_at",
+		"updated_at",
+	).From(tableArtist).
+		Where(squirrel.Eq{"id": id}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	var artist domain.Artist
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(
+		&artist.ID,
+		&artist.Name,
+		&artist.Description,
+		&artist.CreatedAt,
+		&artist.UpdatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrArtistNotFound
+		}
+		return nil, fmt.Errorf("error get artist: %w", err)
+	}
+
+	return &artist, nil
+}
+
+func (r *Repository) GetArtists(ctx context.Context, filter *domain.ArtistRequest) ([]domain.Artist, error) {
+	where := squirrel.And{}
+	if filter.Name != "" {
+		where = append(where, squirrel.Expr("normalize_name(name) LIKE '%' || normalize_name(?) || '%'", filter.Name))
+	}
+
+	query, args, err := r.pg.Builder.Select(
+		"id",
+		"name",
+		"description",
+		"created_at",
+		"updated_at",
+	).From(tableArtist).
+		Where(where).
+		OrderBy("name", "id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	artists := make([]domain.Artist, 0, filter.Limit)
+	for rows.Next() {
+		var a domain.Artist
+		err := rows.Scan(&a.ID, &a.Name, &a.Description, &a.CreatedAt, &a.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		artists = append(artists, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return artists, nil
+}
+
+// SetFeaturedArtists replaces the featured artists of the song keeping their order.
+func (r *Repository) SetFeaturedArtists(ctx context.Context, songID uuid.UUID, artistIDs []uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Delete(tableSongFeaturedArtist).
+		Where(squirrel.Eq{"song_id": songID}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	_, err = r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error delete featured artists: %w", err)
+	}
+
+	if len(artistIDs) == 0 {
+		return nil
+	}
+
+	builder := r.pg.Builder.
+		Insert(tableSongFeaturedArtist).
+		Columns("song_id", "artist_id", "position").
+		Suffix("ON CONFLICT DO NOTHING")
+	for i, id := range artistIDs {
+		builder = builder.Values(songID, id, i+1)
+	}
+
+	query, args, err = builder.ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	_, err = r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, pgForeignKeyViolation) {
+			return ErrArtistNotFound
+		}
+		return fmt.Errorf("error insert featured artists: %w", err)
+	}
+	return nil
+}
+
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
@@ -1,16 +1,53 @@
 package repo
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/Alina9496/library/internal/domain"
+)
 
 type tansaction string
 
 const (
-	tableSong                    = "songs"
-	suffixReturningID            = "RETURNING id"
-	tansactionKey     tansaction = "tansactionSQL"
+	tableSong                          = "songs"
+	tableArtist                        = "artists"
+	tableSongFeaturedArtist            = "song_featured_artists"
+	tableAlbum                         = "albums"
+	tableAlbumTrack                    = "album_tracks"
+	tableSongRevision                  = "song_revisions"
+	tableIdempotencyKey                = "idempotency_keys"
+	tableSongTranslation               = "song_translations"
+	tableSongImport                    = "song_import"
+	cursorSongExport                   = "song_export"
+	suffixReturningID                  = "RETURNING id"
+	tansactionKey           tansaction = "tansactionSQL"
+
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+
+	// songTextVector must match the expression of songs_text_search_idx.
+	songTextVector  = "to_tsvector('simple', jsonb_path_query_array(s.text, '$[*].text'))"
+	headlineOptions = "StartSel=" + domain.SnippetStart + ", StopSel=" + domain.SnippetStop + ", MaxWords=20, MinWords=5"
+
+	// songExecutor and songName must match the expressions of songs_executor_name_key.
+	songExecutor = "normalize_name(executor)"
+	songName     = "normalize_name(name)"
 )
 
 var (
-	ErrSongNotFound = errors.New("song not found")
-	ErrParserJsonb  = errors.New("error text parser jsonb")
+	ErrSongNotFound     = errors.New("song not found")
+	ErrParserJsonb      = errors.New("error text parser jsonb")
+	ErrArtistNotFound   = errors.New("artist not found")
+	ErrArtistExists     = errors.New("artist already exists")
+	ErrArtistHasSongs   = errors.New("artist has songs or albums")
+	ErrAlbumNotFound    = errors.New("album not found")
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrVersionMismatch  = errors.New("song version mismatch")
+	ErrSongExists       = errors.New("song already exists")
+
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrTranslationExists   = errors.New("translation already exists")
+
+	ErrIdempotencyKeyExists   = errors.New("idempotency key already exists")
+	ErrIdempotencyKeyNotFound = errors.New("idempotency key not found")
 )
@@ -0,0 +1,100 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+// exportFetchSize is the number of songs fetched from the export cursor at once.
+const exportFetchSize = 500
+
+// ExportSongs calls fn for each live song matching the filter in the order of
+// the song list. The songs are read through a cursor, only a fetch of them is kept
+// in memory. It must run in a transaction, the cursor is closed at its end.
+func (r *Repository) ExportSongs(ctx context.Context, filter *domain.SongRequest, fn func(song *domain.Song) error) error {
+	builder := r.pg.Builder.Select(
+		"id",
+		"name",
+		"executor",
+		"artist_id",
+		"text",
+		"link",
+		"release_date",
+		"created_at",
+		"updated_at",
+		"version",
+	).From(tableSong).
+		Where(songFilter(filter))
+	query, args, err := orderSongs(builder, filter).ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	if similarityQuery, similarityArgs := setSimilarity(filter); similarityQuery != "" {
+		_, err = r.conn(ctx).Exec(ctx, similarityQuery, similarityArgs...)
+		if err != nil {
+			return fmt.Errorf("error set similarity: %w", err)
+		}
+	}
+
+	_, err = r.conn(ctx).Exec(ctx, "DECLARE "+cursorSongExport+" NO SCROLL CURSOR FOR "+query, args...)
+	if err != nil {
+		return fmt.Errorf("error declare export cursor: %w", err)
+	}
+
+	fetch := "FETCH " + strconv.Itoa(exportFetchSize) + " FROM " + cursorSongExport
+	for {
+		songs, err := r.fetchSongs(ctx, fetch)
+		if err != nil {
+			return err
+		}
+		for i := range songs {
+			err = fn(&songs[i])
+			if err != nil {
+				return err
+			}
+		}
+		if len(songs) < exportFetchSize {
+			return nil
+		}
+	}
+}
+
+func (r *Repository) fetchSongs(ctx context.Context, fetch string) ([]domain.Song, error) {
+	rows, err := r.conn(ctx).Query(ctx, fetch)
+	if err != nil {
+		return nil, fmt.Errorf("error fetch songs: %w", err)
+	}
+
+	defer rows.Close()
+
+	songs := make([]domain.Song, 0, exportFetchSize)
+	for rows.Next() {
+		var s domain.Song
+		err := rows.Scan(
+			&s.ID,
+			&s.Name,
+			&s.Group,
+			&s.ArtistID,
+			&s.Text,
+			&s.Link,
+			&s.ReleaseDate,
+			&s.CreatedAt,
+			&s.UpdatedAt,
+			&s.Version,
+		)
+		if err != nil {
+			return nil, err
+		}
+		songs = append(songs, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error fetch songs: %w", err)
+	}
+
+	return songs, nil
+}
@@ -0,0 +1,147 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// ReserveIdempotencyKey stores the key of the request in progress. A key
+// created before expiredBefore, or still in progress since before
+// leaseExpiredBefore, is taken over, otherwise ErrIdempotencyKeyExists
+// is returned.
+func (r *Repository) ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, expiredBefore, leaseExpiredBefore time.Time) error {
+	query, args, err := r.pg.Builder.
+		Insert(tableIdempotencyKey).
+		Columns(
+			"key",
+			"owner",
+			"request_hash",
+			"created_at",
+		).
+		Values(
+			key.Key,
+			key.Owner,
+			key.RequestHash,
+			key.CreatedAt,
+		).
+		Suffix(`ON CONFLICT (key) DO UPDATE SET
+			owner = EXCLUDED.owner,
+			request_hash = EXCLUDED.request_hash,
+			status = 0,
+			response = NULL,
+			created_at = EXCLUDED.created_at
+			WHERE `+tableIdempotencyKey+`.created_at < ?
+				OR (`+tableIdempotencyKey+`.status = 0 AND `+tableIdempotencyKey+`.created_at < ?)`, expiredBefore, leaseExpiredBefore).
+		Suffix("RETURNING key").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	var reserved string
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&reserved)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrIdempotencyKeyExists
+		}
+		return fmt.Errorf("error reserve idempotency key: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	query, args, err := r.pg.Builder.Select(
+		"key",
+		"request_hash",
+		"status",
+		"response",
+		"created_at",
+	).From(tableIdempotencyKey).
+		Where(squirrel.Eq{"key": key}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	var k domain.IdempotencyKey
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(
+		&k.Key,
+		&k.RequestHash,
+		&k.Status,
+		&k.Response,
+		&k.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrIdempotencyKeyNotFound
+		}
+		return nil, fmt.Errorf("error get idempotency key: %w", err)
+	}
+
+	return &k, nil
+}
+
+// CompleteIdempotencyKey stores the response to the request with the key
+// while the request of the owner holds it.
+func (r *Repository) CompleteIdempotencyKey(ctx context.Context, key string, owner uuid.UUID, status int, response []byte) error {
+	query, args, err := r.pg.Builder.
+		Update(tableIdempotencyKey).
+		Set("status", status).
+		Set("response", response).
+		Where(squirrel.Eq{"key": key, "owner": owner, "status": 0}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error complete idempotency key: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrIdempotencyKeyNotFound
+	}
+	return nil
+}
+
+// DeleteIdempotencyKey removes the key of the request of the owner
+// in progress, so the request can be retried.
+func (r *Repository) DeleteIdempotencyKey(ctx context.Context, key string, owner uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Delete(tableIdempotencyKey).
+		Where(squirrel.Eq{"key": key, "owner": owner, "status": 0}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	_, err = r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error delete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeysBefore removes keys created before the given time and returns their number.
+func (r *Repository) PurgeIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error) {
+	query, args, err := r.pg.Builder.
+		Delete(tableIdempotencyKey).
+		Where(squirrel.Lt{"created_at": before}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error purge idempotency keys: %w", err)
+	}
+	return commandTag.RowsAffected(), nil
+}
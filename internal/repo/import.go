@@ -0,0 +1,95 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+var importColumns = []string{
+	"id",
+	"name",
+	"executor",
+	"artist_id",
+	"text",
+	"link",
+	"release_date",
+	"created_at",
+	"updated_at",
+}
+
+// ImportSongs inserts the songs with their IDs already set and returns the IDs
+// of the inserted ones. The songs whose group and name are taken by a live song
+// or by an earlier song of the batch are skipped. It must run in a transaction:
+// the songs are copied to a temporary table dropped on commit.
+func (r *Repository) ImportSongs(ctx context.Context, songs []domain.Song) ([]uuid.UUID, error) {
+	_, err := r.conn(ctx).Exec(ctx,
+		"CREATE TEMP TABLE "+tableSongImport+" (LIKE "+tableSong+" INCLUDING DEFAULTS, n int) ON COMMIT DROP")
+	if err != nil {
+		return nil, fmt.Errorf("error create import table: %w", err)
+	}
+
+	now := time.Now()
+	_, err = r.conn(ctx).CopyFrom(ctx,
+		pgx.Identifier{tableSongImport},
+		append(importColumns, "n"),
+		pgx.CopyFromSlice(len(songs), func(i int) ([]any, error) {
+			song := songs[i]
+			return []any{
+				song.ID,
+				song.Name,
+				song.Group,
+				song.ArtistID,
+				song.Text,
+				song.Link,
+				song.ReleaseDate,
+				now,
+				now,
+				i,
+			}, nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error copy songs: %w", err)
+	}
+
+	query, args, err := r.pg.Builder.
+		Insert(tableSong).
+		Columns(importColumns...).
+		Select(squirrel.Select(importColumns...).
+			From(tableSongImport).
+			OrderBy("n")).
+		Suffix("ON CONFLICT DO NOTHING " + suffixReturningID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error import songs: %w", err)
+	}
+
+	defer rows.Close()
+
+	ids := make([]uuid.UUID, 0, len(songs))
+	for rows.Next() {
+		var id uuid.UUID
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error import songs: %w", err)
+	}
+
+	return ids, nil
+}
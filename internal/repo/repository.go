@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Alina9496/library/internal/domain"
@@ -32,6 +34,8 @@ type db interface {
 	Exec(ctx context.Context, sql string, arguments ...interface{}) (commandTag pgconn.CommandTag, err error)
 	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
 }
 
 func (r *Repository) conn(ctx context.Context) db {
@@ -41,7 +45,9 @@ func (r *Repository) conn(ctx context.Context) db {
 	return r.pg.Pool
 }
 
-func (r *Repository) ExecTx(ctx context.Context, fn func(ctx context.Context) error) error {
+// ExecTx runs fn in a transaction, nested calls join the outer one.
+// The transaction is rolled back when fn returns an error or panics.
+func (r *Repository) ExecTx(ctx context.Context, fn func(ctx context.Context) error) (err error) {
 	if _, ok := ctx.Value(tansactionKey).(pgx.Tx); ok {
 		return fn(ctx)
 	}
@@ -61,8 +67,15 @@ func (r *Repository) ExecTx(ctx context.Context, fn func(ctx context.Context) er
 			err = fmt.Errorf("panic :%s", p)
 			return
 		}
+		if err != nil {
+			if errRollback := tx.Rollback(ctx); errRollback != nil {
+				r.l.Error("rollback err %s", errRollback)
+			}
+			return
+		}
 		if errCommit := tx.Commit(ctx); errCommit != nil {
 			r.l.Error("commit err %s", errCommit)
+			err = errCommit
 		}
 	}()
 	return fn(ctx)
@@ -75,6 +88,7 @@ func (r *Repository) Create(ctx context.Context, song *domain.Song) (*uuid.UUID,
 		Columns(
 			"name",
 			"executor",
+			"artist_id",
 			"text",
 			"link",
 			"release_date",
@@ -84,6 +98,7 @@ func (r *Repository) Create(ctx context.Context, song *domain.Song) (*uuid.UUID,
 		Values(
 			song.Name,
 			song.Group,
+			song.ArtistID,
 			song.Text,
 			song.Link,
 			song.ReleaseDate,
@@ -99,26 +114,32 @@ func (r *Repository) Create(ctx context.Context, song *domain.Song) (*uuid.UUID,
 	var id uuid.UUID
 	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&id)
 	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return nil, ErrSongExists
+		}
 		return nil, fmt.Errorf("error create: %w", err)
 	}
 
 	return &id, nil
 }
 
+// Update overwrites the song. A non-zero song version must match the stored one.
 func (r *Repository) Update(ctx context.Context, song *domain.Song) error {
 	valuesMap := map[string]any{
 		"name":         song.Name,
 		"executor":     song.Group,
+		"artist_id":    song.ArtistID,
 		"text":         song.Text,
 		"link":         song.Link,
 		"release_date": song.ReleaseDate,
 		"updated_at":   time.Now(),
+		"version":      squirrel.Expr("version + 1"),
 	}
 
 	query, args, err := r.pg.Builder.
 		Update(tableSong).
 		SetMap(valuesMap).
-		Where(squirrel.Eq{"id": song.ID}).
+		Where(songVersion(song.ID, song.Version)).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("error build query: %w", err)
@@ -126,18 +147,24 @@ func (r *Repository) Update(ctx context.Context, song *domain.Song) error {
 
 	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
 	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return ErrSongExists
+		}
 		return fmt.Errorf("error update: %w", err)
 	}
 	if commandTag.RowsAffected() == 0 {
-		return ErrSongNotFound
+		return r.notChanged(ctx, song.ID)
 	}
 	return nil
 }
 
-func (r *Repository) Delete(ctx context.Context, id *uuid.UUID) error {
+// Delete moves the song to the trash, it is removed for good by Purge.
+// A non-zero version must match the stored one.
+func (r *Repository) Delete(ctx context.Context, id *uuid.UUID, version int) error {
 	query, args, err := r.pg.Builder.
-		Delete(tableSong).
-		Where(squirrel.Eq{"id": id}).
+		Update(tableSong).
+		Set("deleted_at", time.Now()).
+		Where(songVersion(*id, version)).
 		ToSql()
 	if err != nil {
 		return fmt.Errorf("error build query: %w", err)
@@ -148,24 +175,58 @@ func (r *Repository) Delete(ctx context.Context, id *uuid.UUID) error {
 		return fmt.Errorf("error delete: %w", err)
 	}
 	if commandTag.RowsAffected() == 0 {
-		return ErrSongNotFound
+		return r.notChanged(ctx, *id)
 	}
 	return nil
 }
 
-func (r *Repository) GetTextSong(ctx context.Context, filter *domain.SongRequest) (domain.SongText, error) {
+// songVersion matches the live song, with a non-zero version only in that version.
+func songVersion(id uuid.UUID, version int) squirrel.Eq {
+	where := squirrel.Eq{"id": id, "deleted_at": nil}
+	if version > 0 {
+		where["version"] = version
+	}
+	return where
+}
+
+// notChanged explains why a change of the song affected no rows.
+func (r *Repository) notChanged(ctx context.Context, id uuid.UUID) error {
 	query, args, err := r.pg.Builder.
-		Select("text").
+		Select("1").
 		From(tableSong).
-		Where(squirrel.Eq{"executor": filter.Group}).
-		Where(squirrel.Eq{"name": filter.Name}).
+		Where(squirrel.Eq{"id": id, "deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	var exists int
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrSongNotFound
+		}
+		return fmt.Errorf("error check song: %w", err)
+	}
+	return ErrVersionMismatch
+}
+
+// GetTextSong returns the lyrics of the song with its ID and version.
+// The group and the name are matched ignoring case and extra whitespace.
+func (r *Repository) GetTextSong(ctx context.Context, filter *domain.SongRequest) (*domain.Song, error) {
+	query, args, err := r.pg.Builder.
+		Select("id", "text", "version").
+		From(tableSong).
+		Where(songExecutor+" = normalize_name(?)", filter.Group).
+		Where(songName+" = normalize_name(?)", filter.Name).
+		Where(squirrel.Eq{"deleted_at": nil}).
 		ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("error build query: %w", err)
 	}
 
-	var text domain.SongText
-	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&text)
+	var song domain.Song
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&song.ID, &song.Text, &song.Version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrSongNotFound
@@ -173,16 +234,276 @@ func (r *Repository) GetTextSong(ctx context.Context, filter *domain.SongRequest
 		return nil, fmt.Errorf("error get text song: %w", err)
 	}
 
-	return text, nil
+	return &song, nil
 }
 
-func (r *Repository) GetSongs(ctx context.Context, filter *domain.SongRequest) ([]domain.Song, error) {
-	where := squirrel.And{}
+func (r *Repository) GetSongs(ctx context.Context, filter *domain.SongRequest) (*domain.SongList, error) {
+	where := songFilter(filter)
+	if filter.After != nil {
+		where = append(where, squirrel.Expr("(created_at, id) > (?, ?)", filter.After.CreatedAt, filter.After.ID))
+	}
+
+	builder := r.pg.Builder.Select(
+		"id",
+		"name",
+		"executor",
+		"artist_id",
+		"link",
+		"release_date",
+		"created_at",
+		"updated_at",
+		"version",
+	).From(tableSong).
+		Where(where).
+		Limit(uint64(filter.Limit))
+	builder = orderSongs(builder, filter)
+	if !filter.Keyset {
+		builder = builder.Offset(uint64(filter.Offset))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	countQuery, countArgs, err := r.songCounts(filter).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	// the page and the counts are sent in one batch to make one round trip,
+	// the batch runs in one transaction
+	batch := &pgx.Batch{}
+	similarityQuery, similarityArgs := setSimilarity(filter)
+	if similarityQuery != "" {
+		batch.Queue(similarityQuery, similarityArgs...)
+	}
+	batch.Queue(query, args...)
+	batch.Queue(countQuery, countArgs...)
+	results := r.conn(ctx).SendBatch(ctx, batch)
+	defer results.Close()
+
+	if similarityQuery != "" {
+		_, err = results.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("error set similarity: %w", err)
+		}
+	}
+
+	list := &domain.SongList{Songs: make([]domain.Song, 0, filter.Limit)}
+	rows, err := results.Query()
+	if err != nil {
+		return nil, err
+	}
+
+	for rows.Next() {
+		var s domain.Song
+		err := rows.Scan(&s.ID, &s.Name, &s.Group, &s.ArtistID, &s.Link, &s.ReleaseDate, &s.CreatedAt, &s.UpdatedAt, &s.Version)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		list.Songs = append(list.Songs, s)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	err = scanSongCounts(results, list)
+	if err != nil {
+		return nil, err
+	}
+
+	err = results.Close()
+	if err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// facetColumns are the expressions of the facets of the song list,
+// the sections come from the sectionTypes join.
+var facetColumns = map[domain.SongFacet]string{
+	domain.FacetGroup:   "executor",
+	domain.FacetYear:    "EXTRACT(YEAR FROM release_date)::int::text",
+	domain.FacetSection: "sections.type",
+}
+
+const sectionTypes = `LEFT JOIN LATERAL (
+		SELECT DISTINCT item->>'type' AS type FROM jsonb_array_elements(` + tableSong + `.text) AS item
+	) sections ON true`
+
+// facetSize limits the values of a facet to the largest counts.
+const facetSize = 50
+
+// songCounts counts the songs matching the filter with the grouping sets of
+// the requested facets. The row of the empty grouping set has the total.
+func (r *Repository) songCounts(filter *domain.SongRequest) squirrel.SelectBuilder {
+	facet := make([]string, 0, len(filter.Facets))
+	value := make([]string, 0, len(filter.Facets))
+	sets := []string{"()"}
+	sections := false
+	for _, f := range filter.Facets {
+		column := facetColumns[f]
+		facet = append(facet, "WHEN GROUPING("+column+") = 0 THEN '"+string(f)+"'")
+		value = append(value, "WHEN GROUPING("+column+") = 0 THEN "+column)
+		sets = append(sets, "("+column+")")
+		sections = sections || f == domain.FacetSection
+	}
+
+	count := "count(*)"
+	if sections {
+		// the join repeats a song for each type of its sections
+		count = "count(DISTINCT id)"
+	}
+
+	builder := r.pg.Builder.Select(caseOf(facet), caseOf(value), count).From(tableSong)
+	if sections {
+		builder = builder.JoinClause(sectionTypes)
+	}
+	return builder.
+		Where(songFilter(filter)).
+		GroupBy("GROUPING SETS ("+strings.Join(sets, ", ")+")").
+		OrderBy("1 NULLS FIRST", "3 DESC", "2")
+}
+
+func caseOf(whens []string) string {
+	if len(whens) == 0 {
+		return "NULL::text"
+	}
+	return "CASE " + strings.Join(whens, " ") + " END"
+}
+
+// scanSongCounts reads the total and the facets of the song list
+// from the result of songCounts.
+func scanSongCounts(results pgx.BatchResults, list *domain.SongList) error {
+	rows, err := results.Query()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var facet, value *string
+		var count int
+		err = rows.Scan(&facet, &value, &count)
+		if err != nil {
+			return err
+		}
+		if facet == nil {
+			list.Total = count
+			continue
+		}
+		// songs without lyrics have no section
+		if value == nil {
+			continue
+		}
+
+		if list.Facets == nil {
+			list.Facets = make(map[domain.SongFacet][]domain.FacetCount)
+		}
+		key := domain.SongFacet(*facet)
+		if len(list.Facets[key]) < facetSize {
+			list.Facets[key] = append(list.Facets[key], domain.FacetCount{Value: *value, Count: count})
+		}
+	}
+
+	return rows.Err()
+}
+
+// sortColumns are the columns of the song list sort fields.
+var sortColumns = map[domain.SongSortField]string{
+	domain.SortName:        "name",
+	domain.SortGroup:       "executor",
+	domain.SortReleaseDate: "release_date",
+	domain.SortCreatedAt:   "created_at",
+}
+
+// songOrder is the ORDER BY of the song list, the id breaks the ties
+// so that the pages don't overlap.
+func songOrder(filter *domain.SongRequest) []string {
+	if len(filter.Sort) == 0 {
+		return []string{"created_at", "id"}
+	}
+
+	order := make([]string, 0, len(filter.Sort)+1)
+	for _, key := range filter.Sort {
+		column := sortColumns[key.Field]
+		if key.Desc {
+			column += " DESC"
+		}
+		order = append(order, column)
+	}
+	return append(order, "id")
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// fuzzyMatch matches the column ignoring case, accents and extra whitespace
+// when it contains the value or has a word similar to it. The similarity
+// threshold is set by setSimilarity.
+func fuzzyMatch(column, value string) squirrel.Sqlizer {
+	return squirrel.Expr(
+		"(search_name("+column+") ILIKE search_name(?) OR search_name(?) <% search_name("+column+"))",
+		"%"+likeEscaper.Replace(value)+"%", value,
+	)
+}
+
+// setSimilarity sets the similarity threshold of fuzzyMatch until the end
+// of the transaction, the query is empty when the filter has no fuzzy match.
+func setSimilarity(filter *domain.SongRequest) (string, []interface{}) {
+	if filter.Group == "" && filter.Name == "" {
+		return "", nil
+	}
+	return similarityThreshold(filter.Similarity)
+}
+
+// similarityThreshold sets the least word similarity of the <% operator until
+// the end of the transaction, the query is empty for the default threshold.
+func similarityThreshold(similarity float64) (string, []interface{}) {
+	if similarity <= 0 {
+		return "", nil
+	}
+	return "SELECT set_config('pg_trgm.word_similarity_threshold', $1, true)",
+		[]interface{}{strconv.FormatFloat(similarity, 'f', -1, 64)}
+}
+
+// orderSongs orders the song list by songOrder. Without a sort the songs of
+// a fuzzy match of the group or the name go from the most similar.
+func orderSongs(builder squirrel.SelectBuilder, filter *domain.SongRequest) squirrel.SelectBuilder {
+	if len(filter.Sort) > 0 || filter.Keyset {
+		return builder.OrderBy(songOrder(filter)...)
+	}
+
+	var (
+		rank []string
+		args []interface{}
+	)
 	if filter.Group != "" {
-		where = append(where, squirrel.Like{"executor": "%" + filter.Group + "%"})
+		rank = append(rank, "word_similarity(search_name(?), search_name(executor))")
+		args = append(args, filter.Group)
 	}
 	if filter.Name != "" {
-		where = append(where, squirrel.Like{"name": "%" + filter.Name + "%"})
+		rank = append(rank, "word_similarity(search_name(?), search_name(name))")
+		args = append(args, filter.Name)
+	}
+	if len(rank) > 0 {
+		builder = builder.OrderByClause(strings.Join(rank, " + ")+" DESC", args...)
+	}
+	return builder.OrderBy(songOrder(filter)...)
+}
+
+// songFilter matches the live songs by the fields of the filter.
+func songFilter(filter *domain.SongRequest) squirrel.And {
+	where := squirrel.And{squirrel.Eq{"deleted_at": nil}}
+	if filter.Group != "" {
+		where = append(where, fuzzyMatch("executor", filter.Group))
+	}
+	if filter.Name != "" {
+		where = append(where, fuzzyMatch("name", filter.Name))
 	}
 	if filter.Link != "" {
 		where = append(where, squirrel.Like{"link": "%" + filter.Link + "%"})
@@ -190,14 +511,62 @@ func (r *Repository) GetSongs(ctx context.Context, filter *domain.SongRequest) (
 	if !filter.ReleaseDate.IsZero() {
 		where = append(where, squirrel.Eq{"release_date": filter.ReleaseDate})
 	}
+	if !filter.ReleaseFrom.IsZero() {
+		where = append(where, squirrel.GtOrEq{"release_date": filter.ReleaseFrom})
+	}
+	if !filter.ReleaseTo.IsZero() {
+		where = append(where, squirrel.LtOrEq{"release_date": filter.ReleaseTo})
+	}
+	if filter.Year != 0 {
+		where = append(where,
+			squirrel.GtOrEq{"release_date": time.Date(filter.Year, time.January, 1, 0, 0, 0, 0, time.UTC)},
+			squirrel.Lt{"release_date": time.Date(filter.Year+1, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		)
+	}
+	if !filter.CreatedSince.IsZero() {
+		where = append(where, squirrel.GtOrEq{"created_at": filter.CreatedSince})
+	}
+	if !filter.UpdatedSince.IsZero() {
+		where = append(where, squirrel.GtOrEq{"updated_at": filter.UpdatedSince})
+	}
+	if filter.ArtistID != uuid.Nil {
+		where = append(where, squirrel.Or{
+			squirrel.Eq{"artist_id": filter.ArtistID},
+			squirrel.Expr("id IN (SELECT song_id FROM "+tableSongFeaturedArtist+" WHERE artist_id = ?)", filter.ArtistID),
+		})
+	}
+
+	if filter.AlbumID != uuid.Nil {
+		where = append(where, squirrel.Expr("id IN (SELECT song_id FROM "+tableAlbumTrack+" WHERE album_id = ?)", filter.AlbumID))
+	}
+	return where
+}
+
+// SearchSongs finds songs by lyrics ranked by relevance.
+// Every result carries the best matching section with a highlighted snippet.
+func (r *Repository) SearchSongs(ctx context.Context, filter *domain.SearchRequest) ([]domain.SearchResult, error) {
+	matchedSection := `LEFT JOIN LATERAL (
+		SELECT item.ord, item.value->>'type' AS type, item.value->>'text' AS text
+		FROM jsonb_array_elements(s.text) WITH ORDINALITY AS item(value, ord)
+		WHERE to_tsvector('simple', item.value->>'text') @@ q
+		ORDER BY ts_rank(to_tsvector('simple', item.value->>'text'), q) DESC, item.ord
+		LIMIT 1
+	) m ON true`
 
 	query, args, err := r.pg.Builder.Select(
-		"name",
-		"executor",
-		"link",
-		"release_date",
-	).From(tableSong).
-		Where(where).
+		"s.id",
+		"s.name",
+		"s.executor",
+		"ts_rank("+songTextVector+", q)::float8 AS rank",
+		"COALESCE(m.ord, 0)",
+		"COALESCE(m.type, '')",
+		"COALESCE(ts_headline('simple', m.text, q, '"+headlineOptions+"'), '')",
+	).From(tableSong+" s").
+		CrossJoin("websearch_to_tsquery('simple', ?) q", filter.Query).
+		JoinClause(matchedSection).
+		Where(songTextVector+" @@ q").
+		Where(squirrel.Eq{"s.deleted_at": nil}).
+		OrderBy("rank DESC", "s.id").
 		Limit(uint64(filter.Limit)).
 		Offset(uint64(filter.Offset)).
 		ToSql()
@@ -205,26 +574,135 @@ func (r *Repository) GetSongs(ctx context.Context, filter *domain.SongRequest) (
 		return nil, fmt.Errorf("error build query: %w", err)
 	}
 
-	songs := make([]domain.Song, 0, filter.Limit)
 	rows, err := r.conn(ctx).Query(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("error search songs: %w", err)
 	}
 
 	defer rows.Close()
 
+	results := make([]domain.SearchResult, 0, filter.Limit)
 	for rows.Next() {
-		var s domain.Song
-		err := rows.Scan(&s.Name, &s.Group, &s.Link, &s.ReleaseDate)
+		var res domain.SearchResult
+		err := rows.Scan(
+			&res.ID,
+			&res.Name,
+			&res.Group,
+			&res.Rank,
+			&res.SectionIndex,
+			&res.SectionType,
+			&res.Snippet,
+		)
 		if err != nil {
 			return nil, err
 		}
-		songs = append(songs, s)
+		results = append(results, res)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
 
-	return songs, nil
+	return results, nil
+}
+
+// GetSong returns the song with its featured artists.
+// With lock the row is locked until the end of the transaction.
+func (r *Repository) GetSong(ctx context.Context, id *uuid.UUID, lock bool) (*domain.Song, error) {
+	builder := r.pg.Builder.Select(
+		"s.id",
+		"s.name",
+		"s.executor",
+		"s.artist_id",
+		"s.text",
+		"s.link",
+		"s.release_date",
+		"s.created_at",
+		"s.updated_at",
+		"s.version",
+		"ARRAY(SELECT fa.artist_id FROM "+tableSongFeaturedArtist+" fa WHERE fa.song_id = s.id ORDER BY fa.position)",
+	).From(tableSong + " s").
+		Where(squirrel.Eq{"s.id": id, "s.deleted_at": nil})
+	if lock {
+		builder = builder.Suffix("FOR UPDATE")
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	var s domain.Song
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(
+		&s.ID,
+		&s.Name,
+		&s.Group,
+		&s.ArtistID,
+		&s.Text,
+		&s.Link,
+		&s.ReleaseDate,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.Version,
+		&s.FeaturedArtistIDs,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrSongNotFound
+		}
+		return nil, fmt.Errorf("error get song: %w", err)
+	}
+
+	return &s, nil
+}
+
+// Patch updates only the changed columns of the song and returns its new version.
+// A non-zero version must match the stored one. Featured artists are stored
+// separately and set by SetFeaturedArtists.
+func (r *Repository) Patch(ctx context.Context, id uuid.UUID, version int, patch *domain.SongPatch) (int, error) {
+	valuesMap := map[string]any{
+		"updated_at": time.Now(),
+		"version":    squirrel.Expr("version + 1"),
+	}
+	if patch.Name != nil {
+		valuesMap["name"] = *patch.Name
+	}
+	if patch.Group != nil {
+		valuesMap["executor"] = *patch.Group
+	}
+	if patch.ArtistID != nil {
+		valuesMap["artist_id"] = *patch.ArtistID
+	}
+	if patch.Text != nil {
+		valuesMap["text"] = *patch.Text
+	}
+	if patch.Link != nil {
+		valuesMap["link"] = *patch.Link
+	}
+	if patch.ReleaseDate != nil {
+		valuesMap["release_date"] = *patch.ReleaseDate
+	}
+
+	query, args, err := r.pg.Builder.
+		Update(tableSong).
+		SetMap(valuesMap).
+		Where(songVersion(id, version)).
+		Suffix("RETURNING version").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("error build query: %w", err)
+	}
+
+	var newVersion int
+	err = r.conn(ctx).QueryRow(ctx, query, args...).Scan(&newVersion)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, r.notChanged(ctx, id)
+		}
+		if isPgError(err, pgUniqueViolation) {
+			return 0, ErrSongExists
+		}
+		return 0, fmt.Errorf("error patch: %w", err)
+	}
+	return newVersion, nil
 }
@@ -0,0 +1,167 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// AddRevision saves the current state of the song as its next revision.
+// It must run in the transaction of the change, the updated song row is
+// locked there, so concurrent changes get sequential revision numbers.
+func (r *Repository) AddRevision(ctx context.Context, songID uuid.UUID) error {
+	commandTag, err := r.addRevisions(ctx, squirrel.Eq{"s.id": songID})
+	if err != nil {
+		return err
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrSongNotFound
+	}
+	return nil
+}
+
+// AddRevisions saves the current state of the songs as their next revisions,
+// as for the songs created by an import or renamed with their artist.
+func (r *Repository) AddRevisions(ctx context.Context, songIDs []uuid.UUID) error {
+	if len(songIDs) == 0 {
+		return nil
+	}
+	_, err := r.addRevisions(ctx, squirrel.Eq{"s.id": songIDs})
+	return err
+}
+
+func (r *Repository) addRevisions(ctx context.Context, where squirrel.Eq) (pgconn.CommandTag, error) {
+	query, args, err := r.pg.Builder.
+		Insert(tableSongRevision).
+		Columns(
+			"song_id",
+			"revision",
+			"name",
+			"executor",
+			"artist_id",
+			"featured_artist_ids",
+			"text",
+			"link",
+			"release_date",
+			"created_at",
+		).
+		Select(squirrel.Select(
+			"s.id",
+			"(SELECT COALESCE(MAX(revision), 0) + 1 FROM "+tableSongRevision+" WHERE song_id = s.id)",
+			"s.name",
+			"s.executor",
+			"s.artist_id",
+			"ARRAY(SELECT fa.artist_id FROM "+tableSongFeaturedArtist+" fa WHERE fa.song_id = s.id ORDER BY fa.position)",
+			"s.text",
+			"s.link",
+			"s.release_date",
+		).
+			Column("?::timestamp", time.Now()).
+			From(tableSong + " s").
+			Where(where)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("error add revision: %w", err)
+	}
+	return commandTag, nil
+}
+
+// GetRevisions returns the revisions of the song, the latest first.
+func (r *Repository) GetRevisions(ctx context.Context, songID *uuid.UUID) ([]domain.SongRevision, error) {
+	query, args, err := r.revisionSelect().
+		Where(squirrel.Eq{"song_id": songID}).
+		OrderBy("revision DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	revisions := make([]domain.SongRevision, 0)
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return revisions, nil
+}
+
+func (r *Repository) GetRevision(ctx context.Context, songID *uuid.UUID, revision int) (*domain.SongRevision, error) {
+	query, args, err := r.revisionSelect().
+		Where(squirrel.Eq{"song_id": songID}).
+		Where(squirrel.Eq{"revision": revision}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rev, err := scanRevision(r.conn(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, fmt.Errorf("error get revision: %w", err)
+	}
+
+	return rev, nil
+}
+
+func (r *Repository) revisionSelect() squirrel.SelectBuilder {
+	return r.pg.Builder.Select(
+		"song_id",
+		"revision",
+		"name",
+		"executor",
+		"artist_id",
+		"featured_artist_ids",
+		"text",
+		"link",
+		"release_date",
+		"created_at",
+	).From(tableSongRevision)
+}
+
+func scanRevision(row pgx.Row) (*domain.SongRevision, error) {
+	var rev domain.SongRevision
+	err := row.Scan(
+		&rev.Song.ID,
+		&rev.Revision,
+		&rev.Song.Name,
+		&rev.Song.Group,
+		&rev.Song.ArtistID,
+		&rev.Song.FeaturedArtistIDs,
+		&rev.Song.Text,
+		&rev.Song.Link,
+		&rev.Song.ReleaseDate,
+		&rev.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
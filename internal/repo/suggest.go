@@ -0,0 +1,80 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/jackc/pgx/v4"
+)
+
+// suggestQuery finds the song names and the artists starting with the query,
+// then the ones with a word similar to it. $1 is the query, $2 is its prefix
+// pattern and $3 is the limit.
+const suggestQuery = `
+	(SELECT 'song' AS kind, id, name AS text, executor AS grp,
+		search_name(name) LIKE search_name($2) AS starts,
+		word_similarity(search_name($1), search_name(name))::float8 AS score
+	FROM ` + tableSong + `
+	WHERE deleted_at IS NULL
+		AND (search_name(name) LIKE search_name($2) OR search_name($1) <% search_name(name))
+	ORDER BY starts DESC, score DESC, text
+	LIMIT $3)
+	UNION ALL
+	(SELECT 'group' AS kind, id, name AS text, '' AS grp,
+		search_name(name) LIKE search_name($2) AS starts,
+		word_similarity(search_name($1), search_name(name))::float8 AS score
+	FROM ` + tableArtist + `
+	WHERE search_name(name) LIKE search_name($2) OR search_name($1) <% search_name(name)
+	ORDER BY starts DESC, score DESC, text
+	LIMIT $3)
+	ORDER BY starts DESC, score DESC, text
+	LIMIT $3`
+
+// Suggest returns the song names and the groups for a type-ahead,
+// those starting with the query go first.
+func (r *Repository) Suggest(ctx context.Context, filter *domain.SuggestRequest) ([]domain.Suggestion, error) {
+	batch := &pgx.Batch{}
+	similarityQuery, similarityArgs := similarityThreshold(filter.Similarity)
+	if similarityQuery != "" {
+		batch.Queue(similarityQuery, similarityArgs...)
+	}
+	batch.Queue(suggestQuery, filter.Query, likeEscaper.Replace(filter.Query)+"%", filter.Limit)
+	results := r.conn(ctx).SendBatch(ctx, batch)
+	defer results.Close()
+
+	if similarityQuery != "" {
+		_, err := results.Exec()
+		if err != nil {
+			return nil, fmt.Errorf("error set similarity: %w", err)
+		}
+	}
+
+	rows, err := results.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	suggestions := make([]domain.Suggestion, 0, filter.Limit)
+	for rows.Next() {
+		var (
+			s      domain.Suggestion
+			kind   string
+			starts bool
+			score  float64
+		)
+		err = rows.Scan(&kind, &s.ID, &s.Text, &s.Group, &starts, &score)
+		if err != nil {
+			return nil, err
+		}
+		s.Kind = domain.SuggestionKind(kind)
+		suggestions = append(suggestions, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return suggestions, nil
+}
@@ -0,0 +1,153 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+func (r *Repository) CreateTranslation(ctx context.Context, t *domain.Translation) error {
+	now := time.Now()
+	query, args, err := r.pg.Builder.
+		Insert(tableSongTranslation).
+		Columns(
+			"song_id",
+			"lang",
+			"text",
+			"sources",
+			"created_at",
+			"updated_at",
+		).
+		Values(
+			t.SongID,
+			t.Lang,
+			t.Text,
+			t.Sources,
+			now,
+			now,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	_, err = r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return ErrTranslationExists
+		}
+		if isPgError(err, pgForeignKeyViolation) {
+			return ErrSongNotFound
+		}
+		return fmt.Errorf("error create translation: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) UpdateTranslation(ctx context.Context, t *domain.Translation) error {
+	query, args, err := r.pg.Builder.
+		Update(tableSongTranslation).
+		SetMap(map[string]any{
+			"text":       t.Text,
+			"sources":    t.Sources,
+			"updated_at": time.Now(),
+		}).
+		Where(squirrel.Eq{"song_id": t.SongID, "lang": t.Lang}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error update translation: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrTranslationNotFound
+	}
+	return nil
+}
+
+func (r *Repository) GetTranslation(ctx context.Context, songID uuid.UUID, lang string) (*domain.Translation, error) {
+	query, args, err := r.translationSelect().
+		Where(squirrel.Eq{"song_id": songID, "lang": lang}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	t, err := scanTranslation(r.conn(ctx).QueryRow(ctx, query, args...))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrTranslationNotFound
+		}
+		return nil, fmt.Errorf("error get translation: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *Repository) GetTranslations(ctx context.Context, songID uuid.UUID) ([]domain.Translation, error) {
+	query, args, err := r.translationSelect().
+		Where(squirrel.Eq{"song_id": songID}).
+		OrderBy("lang").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	translations := make([]domain.Translation, 0)
+	for rows.Next() {
+		t, err := scanTranslation(rows)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, *t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+func (r *Repository) translationSelect() squirrel.SelectBuilder {
+	return r.pg.Builder.Select(
+		"song_id",
+		"lang",
+		"text",
+		"sources",
+		"created_at",
+		"updated_at",
+	).From(tableSongTranslation)
+}
+
+func scanTranslation(row pgx.Row) (*domain.Translation, error) {
+	var t domain.Translation
+	err := row.Scan(
+		&t.SongID,
+		&t.Lang,
+		&t.Text,
+		&t.Sources,
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
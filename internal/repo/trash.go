@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// GetTrash returns deleted songs, the most recently deleted first.
+func (r *Repository) GetTrash(ctx context.Context, filter *domain.TrashRequest) ([]domain.Song, error) {
+	query, args, err := r.pg.Builder.Select(
+		"id",
+		"name",
+		"executor",
+		"artist_id",
+		"link",
+		"release_date",
+		"created_at",
+		"deleted_at",
+	).From(tableSong).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		OrderBy("deleted_at DESC", "id").
+		Limit(uint64(filter.Limit)).
+		Offset(uint64(filter.Offset)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("error build query: %w", err)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	songs := make([]domain.Song, 0, filter.Limit)
+	for rows.Next() {
+		var s domain.Song
+		err := rows.Scan(&s.ID, &s.Name, &s.Group, &s.ArtistID, &s.Link, &s.ReleaseDate, &s.CreatedAt, &s.DeletedAt)
+		if err != nil {
+			return nil, err
+		}
+		songs = append(songs, s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return songs, nil
+}
+
+// Restore takes the song out of the trash unless a live song with the same
+// group and name exists.
+func (r *Repository) Restore(ctx context.Context, id *uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Update(tableSong).
+		Set("deleted_at", nil).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		if isPgError(err, pgUniqueViolation) {
+			return ErrSongExists
+		}
+		return fmt.Errorf("error restore: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrSongNotFound
+	}
+	return nil
+}
+
+// Purge removes the song from the trash for good.
+func (r *Repository) Purge(ctx context.Context, id *uuid.UUID) error {
+	query, args, err := r.pg.Builder.
+		Delete(tableSong).
+		Where(squirrel.Eq{"id": id}).
+		Where(squirrel.NotEq{"deleted_at": nil}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("error purge: %w", err)
+	}
+	if commandTag.RowsAffected() == 0 {
+		return ErrSongNotFound
+	}
+	return nil
+}
+
+// PurgeDeletedBefore removes songs deleted before the given time and returns their number.
+func (r *Repository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query, args, err := r.pg.Builder.
+		Delete(tableSong).
+		Where(squirrel.Lt{"deleted_at": before}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("error build query: %w", err)
+	}
+
+	commandTag, err := r.conn(ctx).Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("error purge deleted songs: %w", err)
+	}
+	return commandTag.RowsAffected(), nil
+}
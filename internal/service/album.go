@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *Service) CreateAlbum(ctx context.Context, album *domain.Album) (*uuid.UUID, error) {
+	l := s.log.WithField("service_method", "CreateAlbum")
+	if album == nil {
+		l.Debug(ErrAlbumIsNil.Error())
+		return nil, ErrAlbumIsNil
+	}
+
+	var id *uuid.UUID
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		err := s.resolveAlbumArtist(ctx, album)
+		if err != nil {
+			return err
+		}
+
+		id, err = s.repo.CreateAlbum(ctx, album)
+		if err != nil {
+			return err
+		}
+
+		if len(album.Tracks) == 0 {
+			return nil
+		}
+		return s.repo.SetAlbumTracks(ctx, *id, trackSongIDs(album.Tracks))
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return nil, ErrSongNotFound
+		}
+		l.WithError(err).Error("error when create album")
+		return nil, fmt.Errorf("error when create album: %w", ErrCreateAlbum)
+	}
+
+	l.WithField("id", id).Info("create album was successfully")
+	return id, nil
+}
+
+// UpdateAlbum updates the album details set in the patch, an artist given
+// by name is created when it doesn't exist.
+func (s *Service) UpdateAlbum(ctx context.Context, patch *domain.AlbumPatch) error {
+	l := s.log.WithField("service_method", "UpdateAlbum")
+	if patch == nil {
+		l.Debug(ErrAlbumIsNil.Error())
+		return ErrAlbumIsNil
+	}
+
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		if patch.ArtistID != nil || patch.Artist != nil {
+			var (
+				artistID uuid.UUID
+				name     string
+			)
+			if patch.ArtistID != nil {
+				artistID = *patch.ArtistID
+			}
+			if patch.Artist != nil {
+				name = *patch.Artist
+			}
+
+			artist, err := s.findArtist(ctx, artistID, name)
+			if err != nil {
+				return err
+			}
+			patch.ArtistID = &artist.ID
+		}
+		return s.repo.UpdateAlbum(ctx, patch)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return ErrAlbumNotFound
+		}
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return ErrArtistNotFound
+		}
+		l.WithError(err).Error("error when update album")
+		return fmt.Errorf("error when update album: %w", ErrUpdateAlbum)
+	}
+
+	l.Info("update album was successfully")
+	return nil
+}
+
+// SetAlbumTracks replaces the track list of the album.
+func (s *Service) SetAlbumTracks(ctx context.Context, albumID *uuid.UUID, songIDs []uuid.UUID) error {
+	l := s.log.WithField("service_method", "SetAlbumTracks")
+	if albumID == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return ErrIDIsNil
+	}
+
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		_, err := s.repo.GetAlbum(ctx, albumID)
+		if err != nil {
+			return err
+		}
+		return s.repo.SetAlbumTracks(ctx, *albumID, songIDs)
+	})
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return ErrAlbumNotFound
+		}
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return ErrSongNotFound
+		}
+		l.WithError(err).Error("error when set album tracks")
+		return fmt.Errorf("error when set album tracks: %w", ErrUpdateAlbum)
+	}
+
+	l.WithField("id", albumID).Info("set album tracks was successfully")
+	return nil
+}
+
+func (s *Service) DeleteAlbum(ctx context.Context, id *uuid.UUID) error {
+	l := s.log.WithField("service_method", "DeleteAlbum")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return ErrIDIsNil
+	}
+
+	err := s.repo.DeleteAlbum(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return ErrAlbumNotFound
+		}
+		l.WithError(err).Error("error when delete album")
+		return fmt.Errorf("error when delete album: %w", ErrDeleteAlbum)
+	}
+
+	l.WithField("id", id).Info("delete album was successfully")
+	return nil
+}
+
+func (s *Service) GetAlbum(ctx context.Context, id *uuid.UUID) (*domain.Album, error) {
+	l := s.log.WithField("service_method", "GetAlbum")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return nil, ErrIDIsNil
+	}
+
+	album, err := s.repo.GetAlbum(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		l.WithError(err).Error("error when getAlbum")
+		return nil, fmt.Errorf("error when getAlbum: %w", ErrGetAlbum)
+	}
+
+	l.Info("the album was found successfully")
+	return album, nil
+}
+
+func (s *Service) GetAlbums(ctx context.Context, filter *domain.AlbumRequest) ([]domain.Album, error) {
+	l := s.log.WithField("service_method", "GetAlbums")
+	if filter == nil {
+		l.Debug(ErrFilterIsNil.Error())
+		return nil, ErrFilterIsNil
+	}
+
+	albums, err := s.repo.GetAlbums(ctx, filter)
+	if err != nil {
+		l.WithError(err).Error("error when getAlbums")
+		return nil, fmt.Errorf("error when getAlbums: %w", ErrGetAlbum)
+	}
+
+	l.Info("the albums was found successfully")
+	return albums, nil
+}
+
+func (s *Service) resolveAlbumArtist(ctx context.Context, album *domain.Album) error {
+	artist, err := s.findArtist(ctx, album.ArtistID, album.Artist)
+	if err != nil {
+		return err
+	}
+
+	album.ArtistID = artist.ID
+	album.Artist = artist.Name
+	return nil
+}
+
+func trackSongIDs(tracks []domain.AlbumTrack) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(tracks))
+	for _, t := range tracks {
+		ids = append(ids, t.SongID)
+	}
+	return ids
+}
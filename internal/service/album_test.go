@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_CreateAlbum() {
+	ctx := context.Background()
+	id := uuid.New()
+	songID := uuid.New()
+	muse := &domain.Artist{ID: uuid.New(), Name: "Muse"}
+	newAlbum := func() *domain.Album {
+		return &domain.Album{
+			Artist: "muse",
+			Title:  "Black Holes and Revelations",
+			Tracks: []domain.AlbumTrack{{Position: 1, SongID: songID}},
+		}
+	}
+	albumNotCreated, albumSongNotFound, album := newAlbum(), newAlbum(), newAlbum()
+	byArtistID := &domain.Album{ArtistID: muse.ID, Title: "Absolution"}
+
+	tests := []struct {
+		name  string
+		album *domain.Album
+		want  *uuid.UUID
+		err   error
+		calls func()
+	}{
+		{
+			name:  "album equal nil",
+			album: nil,
+			want:  nil,
+			err:   ErrAlbumIsNil,
+			calls: func() {},
+		},
+		{
+			name:  "artist not found",
+			album: byArtistID,
+			want:  nil,
+			err:   ErrArtistNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetArtist(ctx, &muse.ID).Return(nil, repo.ErrArtistNotFound)
+			},
+		},
+		{
+			name:  "error create album",
+			album: albumNotCreated,
+			want:  nil,
+			err:   fmt.Errorf("error when create album: %w", ErrCreateAlbum),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(muse, nil)
+				s.repo.EXPECT().CreateAlbum(ctx, albumNotCreated).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name:  "track song not found",
+			album: albumSongNotFound,
+			want:  nil,
+			err:   ErrSongNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(muse, nil)
+				s.repo.EXPECT().CreateAlbum(ctx, albumSongNotFound).Return(&id, nil)
+				s.repo.EXPECT().SetAlbumTracks(ctx, id, []uuid.UUID{songID}).Return(repo.ErrSongNotFound)
+			},
+		},
+		{
+			name:  "album was successfully created",
+			album: album,
+			want:  &id,
+			err:   nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(muse, nil)
+				s.repo.EXPECT().CreateAlbum(ctx, album).Return(&id, nil)
+				s.repo.EXPECT().SetAlbumTracks(ctx, id, []uuid.UUID{songID}).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.CreateAlbum(ctx, tt.album)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_UpdateAlbum() {
+	ctx := context.Background()
+	muse := &domain.Artist{ID: uuid.New(), Name: "Muse"}
+	title, name := "Absolution", "Muse"
+	newPatch := func() *domain.AlbumPatch {
+		return &domain.AlbumPatch{ID: uuid.New(), ArtistID: &muse.ID, Title: &title}
+	}
+	albumNotFound, albumNotUpdated, album := newPatch(), newPatch(), newPatch()
+	titleOnly := &domain.AlbumPatch{ID: uuid.New(), Title: &title}
+	byName := &domain.AlbumPatch{ID: uuid.New(), Artist: &name}
+
+	tests := []struct {
+		name  string
+		patch *domain.AlbumPatch
+		err   error
+		calls func()
+	}{
+		{
+			name:  "album equal nil",
+			patch: nil,
+			err:   ErrAlbumIsNil,
+			calls: func() {},
+		},
+		{
+			name:  "album not found",
+			patch: albumNotFound,
+			err:   ErrAlbumNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetArtist(ctx, &muse.ID).Return(muse, nil)
+				s.repo.EXPECT().UpdateAlbum(ctx, albumNotFound).Return(repo.ErrAlbumNotFound)
+			},
+		},
+		{
+			name:  "error update album",
+			patch: albumNotUpdated,
+			err:   fmt.Errorf("error when update album: %w", ErrUpdateAlbum),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetArtist(ctx, &muse.ID).Return(muse, nil)
+				s.repo.EXPECT().UpdateAlbum(ctx, albumNotUpdated).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name:  "album was successfully updated",
+			patch: album,
+			err:   nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetArtist(ctx, &muse.ID).Return(muse, nil)
+				s.repo.EXPECT().UpdateAlbum(ctx, album).Return(nil)
+			},
+		},
+		{
+			name:  "only title is updated without the artist",
+			patch: titleOnly,
+			err:   nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpdateAlbum(ctx, titleOnly).Return(nil)
+			},
+		},
+		{
+			name:  "artist by name",
+			patch: byName,
+			err:   nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, name).Return(muse, nil)
+				s.repo.EXPECT().UpdateAlbum(ctx, &domain.AlbumPatch{ID: byName.ID, ArtistID: &muse.ID, Artist: &name}).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.UpdateAlbum(ctx, tt.patch)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_SetAlbumTracks() {
+	ctx := context.Background()
+	id := uuid.New()
+	songIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "album not found",
+			id:   &id,
+			err:  ErrAlbumNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetAlbum(ctx, &id).Return(nil, repo.ErrAlbumNotFound)
+			},
+		},
+		{
+			name: "song not found",
+			id:   &id,
+			err:  ErrSongNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetAlbum(ctx, &id).Return(&domain.Album{ID: id}, nil)
+				s.repo.EXPECT().SetAlbumTracks(ctx, id, songIDs).Return(repo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "tracks were successfully set",
+			id:   &id,
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetAlbum(ctx, &id).Return(&domain.Album{ID: id}, nil)
+				s.repo.EXPECT().SetAlbumTracks(ctx, id, songIDs).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.SetAlbumTracks(ctx, tt.id, songIDs)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_DeleteAlbum() {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "album not found",
+			id:   &id,
+			err:  ErrAlbumNotFound,
+			calls: func() {
+				s.repo.EXPECT().DeleteAlbum(ctx, &id).Return(repo.ErrAlbumNotFound)
+			},
+		},
+		{
+			name: "error delete album",
+			id:   &id,
+			err:  fmt.Errorf("error when delete album: %w", ErrDeleteAlbum),
+			calls: func() {
+				s.repo.EXPECT().DeleteAlbum(ctx, &id).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "album was successfully deleted",
+			id:   &id,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().DeleteAlbum(ctx, &id).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.DeleteAlbum(ctx, tt.id)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_GetAlbum() {
+	ctx := context.Background()
+	id := uuid.New()
+	album := &domain.Album{ID: id, Title: "Absolution"}
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		want  *domain.Album
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			want:  nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "album not found",
+			id:   &id,
+			want: nil,
+			err:  ErrAlbumNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetAlbum(ctx, &id).Return(nil, repo.ErrAlbumNotFound)
+			},
+		},
+		{
+			name: "album was found",
+			id:   &id,
+			want: album,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().GetAlbum(ctx, &id).Return(album, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.GetAlbum(ctx, tt.id)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *Service) CreateArtist(ctx context.Context, artist *domain.Artist) (*uuid.UUID, error) {
+	l := s.log.WithField("service_method", "CreateArtist")
+	if artist == nil {
+		l.Debug(ErrArtistIsNil.Error())
+		return nil, ErrArtistIsNil
+	}
+
+	id, err := s.repo.CreateArtist(ctx, artist)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistExists) {
+			return nil, ErrArtistExists
+		}
+		l.WithError(err).Error("error when create artist")
+		return nil, fmt.Errorf("error when create artist: %w", ErrCreateArtist)
+	}
+
+	l.WithField("id", id).Info("create artist was successfully")
+	return id, nil
+}
+
+func (s *Service) UpdateArtist(ctx context.Context, patch *domain.ArtistPatch) error {
+	l := s.log.WithField("service_method", "UpdateArtist")
+	if patch == nil {
+		l.Debug(ErrArtistIsNil.Error())
+		return ErrArtistIsNil
+	}
+
+	err := s.repo.UpdateArtist(ctx, patch)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return ErrArtistNotFound
+		}
+		if errors.Is(err, repo.ErrArtistExists) {
+			return ErrArtistExists
+		}
+		l.WithError(err).Error("error when update artist")
+		return fmt.Errorf("error when update artist: %w", ErrUpdateArtist)
+	}
+
+	l.Info("update artist was successfully")
+	return nil
+}
+
+func (s *Service) DeleteArtist(ctx context.Context, id *uuid.UUID) error {
+	l := s.log.WithField("service_method", "DeleteArtist")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return ErrIDIsNil
+	}
+
+	err := s.repo.DeleteArtist(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return ErrArtistNotFound
+		}
+		if errors.Is(err, repo.ErrArtistHasSongs) {
+			return ErrArtistHasSongs
+		}
+		l.WithError(err).Error("error when delete artist")
+		return fmt.Errorf("error when delete artist: %w", ErrDeleteArtist)
+	}
+
+	l.WithField("id", id).Info("delete artist was successfully")
+	return nil
+}
+
+func (s *Service) GetArtist(ctx context.Context, id *uuid.UUID) (*domain.Artist, error) {
+	l := s.log.WithField("service_method", "GetArtist")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return nil, ErrIDIsNil
+	}
+
+	artist, err := s.repo.GetArtist(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		l.WithError(err).Error("error when getArtist")
+		return nil, fmt.Errorf("error when getArtist: %w", ErrGetArtist)
+	}
+
+	l.Info("the artist was found successfully")
+	return artist, nil
+}
+
+func (s *Service) GetArtists(ctx context.Context, filter *domain.ArtistRequest) ([]domain.Artist, error) {
+	l := s.log.WithField("service_method", "GetArtists")
+	if filter == nil {
+		l.Debug(ErrFilterIsNil.Error())
+		return nil, ErrFilterIsNil
+	}
+
+	artists, err := s.repo.GetArtists(ctx, filter)
+	if err != nil {
+		l.WithError(err).Error("error when getArtists")
+		return nil, fmt.Errorf("error when getArtists: %w", ErrGetArtist)
+	}
+
+	l.Info("the artists was found successfully")
+	return artists, nil
+}
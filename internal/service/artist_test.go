@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_CreateArtist() {
+	ctx := context.Background()
+	id := uuid.New()
+	artist := &domain.Artist{Name: "Lady Gaga"}
+
+	tests := []struct {
+		name   string
+		artist *domain.Artist
+		want   *uuid.UUID
+		err    error
+		calls  func()
+	}{
+		{
+			name:   "artist equal nil",
+			artist: nil,
+			want:   nil,
+			err:    ErrArtistIsNil,
+			calls:  func() {},
+		},
+		{
+			name:   "artist already exists",
+			artist: artist,
+			want:   nil,
+			err:    ErrArtistExists,
+			calls: func() {
+				s.repo.EXPECT().CreateArtist(ctx, artist).Return(nil, repo.ErrArtistExists)
+			},
+		},
+		{
+			name:   "error create artist",
+			artist: artist,
+			want:   nil,
+			err:    fmt.Errorf("error when create artist: %w", ErrCreateArtist),
+			calls: func() {
+				s.repo.EXPECT().CreateArtist(ctx, artist).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name:   "artist was successfully created",
+			artist: artist,
+			want:   &id,
+			err:    nil,
+			calls: func() {
+				s.repo.EXPECT().CreateArtist(ctx, artist).Return(&id, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.CreateArtist(ctx, tt.artist)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_UpdateArtist() {
+	ctx := context.Background()
+	name := "Lady Gaga"
+	artist := &domain.ArtistPatch{ID: uuid.New(), Name: &name}
+
+	tests := []struct {
+		name   string
+		artist *domain.ArtistPatch
+		err    error
+		calls  func()
+	}{
+		{
+			name:   "artist equal nil",
+			artist: nil,
+			err:    ErrArtistIsNil,
+			calls:  func() {},
+		},
+		{
+			name:   "artist not found",
+			artist: artist,
+			err:    ErrArtistNotFound,
+			calls: func() {
+				s.repo.EXPECT().UpdateArtist(ctx, artist).Return(repo.ErrArtistNotFound)
+			},
+		},
+		{
+			name:   "name is taken by another artist",
+			artist: artist,
+			err:    ErrArtistExists,
+			calls: func() {
+				s.repo.EXPECT().UpdateArtist(ctx, artist).Return(repo.ErrArtistExists)
+			},
+		},
+		{
+			name:   "error update artist",
+			artist: artist,
+			err:    fmt.Errorf("error when update artist: %w", ErrUpdateArtist),
+			calls: func() {
+				s.repo.EXPECT().UpdateArtist(ctx, artist).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name:   "artist was successfully updated",
+			artist: artist,
+			err:    nil,
+			calls: func() {
+				s.repo.EXPECT().UpdateArtist(ctx, artist).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.UpdateArtist(ctx, tt.artist)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_DeleteArtist() {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "artist not found",
+			id:   &id,
+			err:  ErrArtistNotFound,
+			calls: func() {
+				s.repo.EXPECT().DeleteArtist(ctx, &id).Return(repo.ErrArtistNotFound)
+			},
+		},
+		{
+			name: "artist has songs",
+			id:   &id,
+			err:  ErrArtistHasSongs,
+			calls: func() {
+				s.repo.EXPECT().DeleteArtist(ctx, &id).Return(repo.ErrArtistHasSongs)
+			},
+		},
+		{
+			name: "artist was successfully deleted",
+			id:   &id,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().DeleteArtist(ctx, &id).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.DeleteArtist(ctx, tt.id)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_GetArtist() {
+	ctx := context.Background()
+	id := uuid.New()
+	artist := &domain.Artist{ID: id, Name: "Lady Gaga"}
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		want  *domain.Artist
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			want:  nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "artist not found",
+			id:   &id,
+			want: nil,
+			err:  ErrArtistNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetArtist(ctx, &id).Return(nil, repo.ErrArtistNotFound)
+			},
+		},
+		{
+			name: "get artist",
+			id:   &id,
+			want: artist,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().GetArtist(ctx, &id).Return(artist, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.GetArtist(ctx, tt.id)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_GetArtists() {
+	ctx := context.Background()
+	filter := &domain.ArtistRequest{Name: "gaga", Limit: 10}
+	res := []domain.Artist{{ID: uuid.New(), Name: "Lady Gaga"}}
+
+	tests := []struct {
+		name   string
+		filter *domain.ArtistRequest
+		want   []domain.Artist
+		err    error
+		calls  func()
+	}{
+		{
+			name:   "filter equal nil",
+			filter: nil,
+			want:   nil,
+			err:    ErrFilterIsNil,
+			calls:  func() {},
+		},
+		{
+			name:   "error get artists",
+			filter: filter,
+			want:   nil,
+			err:    fmt.Errorf("error when getArtists: %w", ErrGetArtist),
+			calls: func() {
+				s.repo.EXPECT().GetArtists(ctx, filter).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name:   "get artists",
+			filter: filter,
+			want:   res,
+			err:    nil,
+			calls: func() {
+				s.repo.EXPECT().GetArtists(ctx, filter).Return(res, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.GetArtists(ctx, tt.filter)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
@@ -1,14 +1,73 @@
 package service
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
 
 var (
-	ErrSongNotFound = errors.New("song not found")
-	ErrSongIsNil    = errors.New("song is nil")
-	ErrIDIsNil      = errors.New("ID is nil")
-	ErrFilterIsNil  = errors.New("filter is nil")
-	ErrCreateSong   = errors.New("song not create")
-	ErrUpdateSong   = errors.New("song not update")
-	ErrDeleteSong   = errors.New("song not delete")
-	ErrGetSong      = errors.New("error get song")
+	ErrSongNotFound      = errors.New("song not found")
+	ErrSongIsNil         = errors.New("song is nil")
+	ErrIDIsNil           = errors.New("ID is nil")
+	ErrFilterIsNil       = errors.New("filter is nil")
+	ErrCreateSong        = errors.New("song not create")
+	ErrUpdateSong        = errors.New("song not update")
+	ErrDeleteSong        = errors.New("song not delete")
+	ErrGetSong           = errors.New("error get song")
+	ErrSearchSongs       = errors.New("error search songs")
+	ErrVersionMismatch   = errors.New("song version mismatch")
+	ErrInvalidPatch      = errors.New("invalid patch")
+	ErrSongExists        = errors.New("song already exists")
+	ErrSectionOutOfRange = errors.New("section index is out of range")
+	ErrLineOutOfRange    = errors.New("line index is out of range")
+	ErrGetTrash          = errors.New("error get trash")
+	ErrRestoreSong       = errors.New("song not restore")
+	ErrPurgeSong         = errors.New("song not purge")
+
+	ErrArtistNotFound = errors.New("artist not found")
+	ErrArtistIsNil    = errors.New("artist is nil")
+	ErrArtistExists   = errors.New("artist already exists")
+	ErrArtistHasSongs = errors.New("artist has songs or albums")
+	ErrCreateArtist   = errors.New("artist not create")
+	ErrUpdateArtist   = errors.New("artist not update")
+	ErrDeleteArtist   = errors.New("artist not delete")
+	ErrGetArtist      = errors.New("error get artist")
+
+	ErrRevisionNotFound = errors.New("revision not found")
+	ErrGetRevision      = errors.New("error get revision")
+
+	ErrAlbumNotFound = errors.New("album not found")
+	ErrAlbumIsNil    = errors.New("album is nil")
+	ErrCreateAlbum   = errors.New("album not create")
+	ErrUpdateAlbum   = errors.New("album not update")
+	ErrDeleteAlbum   = errors.New("album not delete")
+	ErrGetAlbum      = errors.New("error get album")
+
+	ErrTranslationNotFound = errors.New("translation not found")
+	ErrTranslationIsNil    = errors.New("translation is nil")
+	ErrTranslationExists   = errors.New("translation already exists")
+	ErrCreateTranslation   = errors.New("translation not create")
+	ErrUpdateTranslation   = errors.New("translation not update")
+	ErrGetTranslation      = errors.New("error get translation")
+
+	ErrIdempotencyKeyMismatch   = errors.New("idempotency key is used with another request")
+	ErrIdempotencyKeyInProgress = errors.New("request with the idempotency key is in progress")
+	ErrIdempotency              = errors.New("error idempotency key")
+
+	ErrSongInfoNotFound = errors.New("song info not found")
+	ErrGetSongInfo      = errors.New("error get song info")
 )
+
+// SongExistsError carries the ID of the live song with the same group and name.
+type SongExistsError struct {
+	ID uuid.UUID
+}
+
+func (e *SongExistsError) Error() string {
+	return ErrSongExists.Error()
+}
+
+func (e *SongExistsError) Unwrap() error {
+	return ErrSongExists
+}
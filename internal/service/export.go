@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+// ExportSongs calls fn for each live song matching the filter with its lyrics,
+// the earliest created first. The songs are read in one transaction, so the
+// export is a consistent snapshot of the library. An error of fn stops the
+// export and is returned as is.
+func (s *Service) ExportSongs(ctx context.Context, filter *domain.SongRequest, fn func(song *domain.Song) error) error {
+	l := s.log.WithField("service_method", "ExportSongs")
+	if filter == nil {
+		l.Debug(ErrFilterIsNil.Error())
+		return ErrFilterIsNil
+	}
+
+	filter.Similarity = s.searchSimilarity
+	var (
+		count int
+		fnErr error
+	)
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		return s.repo.ExportSongs(ctx, filter, func(song *domain.Song) error {
+			fnErr = fn(song)
+			if fnErr != nil {
+				return fnErr
+			}
+			count++
+			return nil
+		})
+	})
+	if fnErr != nil {
+		l.WithError(fnErr).Warn("export songs was stopped")
+		return fnErr
+	}
+	if err != nil {
+		l.WithError(err).Error("error when export songs")
+		return fmt.Errorf("error when export songs: %w", ErrGetSong)
+	}
+
+	l.WithField("count", count).Info("export songs was successfully")
+	return nil
+}
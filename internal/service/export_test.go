@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_ExportSongs() {
+	ctx := context.Background()
+	filter := &domain.SongRequest{Group: "Muse"}
+	songs := []domain.Song{{ID: uuid.New(), Name: "Uprising"}, {ID: uuid.New(), Name: "Resistance"}}
+	errWrite := errors.New("write error")
+	export := func(_ context.Context, _ *domain.SongRequest, fn func(song *domain.Song) error) error {
+		for i := range songs {
+			err := fn(&songs[i])
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name   string
+		filter *domain.SongRequest
+		fnErr  error
+		wait   []string
+		err    error
+		calls  func()
+	}{
+		{
+			name:   "filter equal nil",
+			filter: nil,
+			err:    ErrFilterIsNil,
+			calls:  func() {},
+		},
+		{
+			name:   "error export",
+			filter: filter,
+			err:    fmt.Errorf("error when export songs: %w", ErrGetSong),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().ExportSongs(ctx, filter, gomock.Any()).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name:   "error write",
+			filter: filter,
+			fnErr:  errWrite,
+			wait:   []string{"Uprising"},
+			err:    errWrite,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().ExportSongs(ctx, filter, gomock.Any()).DoAndReturn(export)
+			},
+		},
+		{
+			name:   "export songs",
+			filter: filter,
+			wait:   []string{"Uprising", "Resistance"},
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().ExportSongs(ctx, filter, gomock.Any()).DoAndReturn(export)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			var names []string
+			err := s.service.ExportSongs(ctx, tt.filter, func(song *domain.Song) error {
+				names = append(names, song.Name)
+				return tt.fnErr
+			})
+			s.Equal(tt.err, err)
+			s.Equal(tt.wait, names)
+		})
+	}
+}
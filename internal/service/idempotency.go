@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+// BeginIdempotent reserves the key for the request with the given hash.
+// It returns the owner token of the reservation when the request should be
+// handled and the stored response when the request with the key was already
+// handled. A request in progress holds the key for the idempotency lease,
+// so a key left by a crashed handler can be reserved again.
+func (s *Service) BeginIdempotent(ctx context.Context, key, requestHash string) (uuid.UUID, *domain.IdempotencyKey, error) {
+	l := s.log.WithField("service_method", "BeginIdempotent")
+
+	now := time.Now()
+	owner := uuid.New()
+	err := s.repo.ReserveIdempotencyKey(ctx, &domain.IdempotencyKey{
+		Key:         key,
+		Owner:       owner,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+	}, now.Add(-s.idempotencyTTL), now.Add(-s.idempotencyLease))
+	if err == nil {
+		return owner, nil, nil
+	}
+	if !errors.Is(err, repo.ErrIdempotencyKeyExists) {
+		l.WithError(err).Error("error when reserve idempotency key")
+		return uuid.Nil, nil, fmt.Errorf("error when reserve idempotency key: %w", ErrIdempotency)
+	}
+
+	stored, err := s.repo.GetIdempotencyKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, repo.ErrIdempotencyKeyNotFound) {
+			return uuid.Nil, nil, ErrIdempotencyKeyInProgress
+		}
+		l.WithError(err).Error("error when get idempotency key")
+		return uuid.Nil, nil, fmt.Errorf("error when get idempotency key: %w", ErrIdempotency)
+	}
+
+	if stored.RequestHash != requestHash {
+		return uuid.Nil, nil, ErrIdempotencyKeyMismatch
+	}
+	if stored.Status == 0 {
+		return uuid.Nil, nil, ErrIdempotencyKeyInProgress
+	}
+
+	l.WithField("key", key).Info("the stored response was found successfully")
+	return uuid.Nil, stored, nil
+}
+
+// CompleteIdempotent stores the response to the request with the key. When
+// the lease has expired and a retry has taken the key over, the response
+// is not stored.
+func (s *Service) CompleteIdempotent(ctx context.Context, key string, owner uuid.UUID, status int, response []byte) error {
+	l := s.log.WithField("service_method", "CompleteIdempotent")
+
+	err := s.repo.CompleteIdempotencyKey(ctx, key, owner, status, response)
+	if errors.Is(err, repo.ErrIdempotencyKeyNotFound) {
+		l.WithField("key", key).Warn("the idempotency key was taken over by another request")
+		return nil
+	}
+	if err != nil {
+		l.WithError(err).Error("error when complete idempotency key")
+		return fmt.Errorf("error when complete idempotency key: %w", ErrIdempotency)
+	}
+	return nil
+}
+
+// ReleaseIdempotent drops the key of the failed request, so it can be retried.
+// A key taken over by another request is left as is.
+func (s *Service) ReleaseIdempotent(ctx context.Context, key string, owner uuid.UUID) error {
+	l := s.log.WithField("service_method", "ReleaseIdempotent")
+
+	err := s.repo.DeleteIdempotencyKey(ctx, key, owner)
+	if err != nil {
+		l.WithError(err).Error("error when release idempotency key")
+		return fmt.Errorf("error when release idempotency key: %w", ErrIdempotency)
+	}
+	return nil
+}
+
+// PurgeIdempotencyKeys removes keys which are older than the replay window.
+func (s *Service) PurgeIdempotencyKeys(ctx context.Context) (int64, error) {
+	l := s.log.WithField("service_method", "PurgeIdempotencyKeys")
+
+	count, err := s.repo.PurgeIdempotencyKeysBefore(ctx, time.Now().Add(-s.idempotencyTTL))
+	if err != nil {
+		l.WithError(err).Error("error when purge idempotency keys")
+		return 0, fmt.Errorf("error when purge idempotency keys: %w", ErrIdempotency)
+	}
+
+	if count > 0 {
+		l.WithField("count", count).Info("expired idempotency keys was purged successfully")
+	}
+	return count, nil
+}
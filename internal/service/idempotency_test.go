@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_BeginIdempotent() {
+	ctx := context.Background()
+	key, hash := "key", "hash"
+	var owner uuid.UUID
+	reserve := func(err error) func(context.Context, *domain.IdempotencyKey, time.Time, time.Time) error {
+		return func(_ context.Context, k *domain.IdempotencyKey, expiredBefore, leaseExpiredBefore time.Time) error {
+			owner = k.Owner
+			s.NotEqual(uuid.Nil, k.Owner)
+			s.Equal(key, k.Key)
+			s.Equal(hash, k.RequestHash)
+			s.Equal(k.CreatedAt.Add(-_defaultIdempotencyTTL), expiredBefore)
+			s.Equal(k.CreatedAt.Add(-_defaultIdempotencyLease), leaseExpiredBefore)
+			return err
+		}
+	}
+	completed := &domain.IdempotencyKey{
+		Key:         key,
+		RequestHash: hash,
+		Status:      200,
+		Response:    []byte(`{"id":"1"}`),
+	}
+
+	tests := []struct {
+		name     string
+		reserved bool
+		want     *domain.IdempotencyKey
+		err      error
+		calls    func()
+	}{
+		{
+			name:     "new key",
+			reserved: true,
+			want:     nil,
+			err:      nil,
+			calls: func() {
+				s.repo.EXPECT().ReserveIdempotencyKey(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(reserve(nil))
+			},
+		},
+		{
+			name: "error reserve key",
+			want: nil,
+			err:  fmt.Errorf("error when reserve idempotency key: %w", ErrIdempotency),
+			calls: func() {
+				s.repo.EXPECT().ReserveIdempotencyKey(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(reserve(errors.ErrUnsupported))
+			},
+		},
+		{
+			name: "key is used with another body",
+			want: nil,
+			err:  ErrIdempotencyKeyMismatch,
+			calls: func() {
+				s.repo.EXPECT().ReserveIdempotencyKey(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(reserve(repo.ErrIdempotencyKeyExists))
+				s.repo.EXPECT().GetIdempotencyKey(ctx, key).Return(&domain.IdempotencyKey{Key: key, RequestHash: "other"}, nil)
+			},
+		},
+		{
+			name: "request is in progress",
+			want: nil,
+			err:  ErrIdempotencyKeyInProgress,
+			calls: func() {
+				s.repo.EXPECT().ReserveIdempotencyKey(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(reserve(repo.ErrIdempotencyKeyExists))
+				s.repo.EXPECT().GetIdempotencyKey(ctx, key).Return(&domain.IdempotencyKey{Key: key, RequestHash: hash}, nil)
+			},
+		},
+		{
+			name: "key was released meanwhile",
+			want: nil,
+			err:  ErrIdempotencyKeyInProgress,
+			calls: func() {
+				s.repo.EXPECT().ReserveIdempotencyKey(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(reserve(repo.ErrIdempotencyKeyExists))
+				s.repo.EXPECT().GetIdempotencyKey(ctx, key).Return(nil, repo.ErrIdempotencyKeyNotFound)
+			},
+		},
+		{
+			name: "stored response is replayed",
+			want: completed,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().ReserveIdempotencyKey(ctx, gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(reserve(repo.ErrIdempotencyKeyExists))
+				s.repo.EXPECT().GetIdempotencyKey(ctx, key).Return(completed, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			gotOwner, got, err := s.service.BeginIdempotent(ctx, key, hash)
+			if tt.reserved {
+				s.Equal(owner, gotOwner)
+			} else {
+				s.Equal(uuid.Nil, gotOwner)
+			}
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_CompleteIdempotent() {
+	ctx := context.Background()
+	owner := uuid.New()
+	response := []byte(`{"id":"1"}`)
+
+	tests := []struct {
+		name  string
+		err   error
+		calls func()
+	}{
+		{
+			name: "error complete key",
+			err:  fmt.Errorf("error when complete idempotency key: %w", ErrIdempotency),
+			calls: func() {
+				s.repo.EXPECT().CompleteIdempotencyKey(ctx, "key", owner, 200, response).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "key was taken over by another request",
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().CompleteIdempotencyKey(ctx, "key", owner, 200, response).Return(repo.ErrIdempotencyKeyNotFound)
+			},
+		},
+		{
+			name: "response was stored",
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().CompleteIdempotencyKey(ctx, "key", owner, 200, response).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.CompleteIdempotent(ctx, "key", owner, 200, response)
+			s.Equal(tt.err, err)
+		})
+	}
+}
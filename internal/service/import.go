@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+// ImportSongs creates a batch of songs of a bulk import in one transaction
+// and reports the outcome of each song in the same order. Songs whose group
+// and name are taken are duplicates, songs with a missing artist or album or
+// without song info are invalid. The error is returned when the whole batch
+// failed.
+func (s *Service) ImportSongs(ctx context.Context, songs []domain.Song) ([]domain.ImportResult, error) {
+	l := s.log.WithField("service_method", "ImportSongs")
+	results := make([]domain.ImportResult, len(songs))
+
+	for i := range songs {
+		err := s.prepareSong(ctx, l, &songs[i])
+		if err != nil {
+			if errors.Is(err, ErrGetAlbum) || errors.Is(err, ErrGetArtist) {
+				return nil, err
+			}
+			results[i] = domain.ImportResult{Status: domain.ImportInvalid, Err: err}
+			continue
+		}
+		songs[i].ID = uuid.New()
+	}
+
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		refs := importRefs{
+			artists: make(map[string]*domain.Artist),
+			albums:  make(map[uuid.UUID]bool),
+		}
+		batch := make([]domain.Song, 0, len(songs))
+		for i := range songs {
+			if results[i].Status != "" {
+				continue
+			}
+			err := s.resolveImportRefs(ctx, &refs, &songs[i])
+			if err != nil {
+				if errors.Is(err, repo.ErrArtistNotFound) {
+					results[i] = domain.ImportResult{Status: domain.ImportInvalid, Err: ErrArtistNotFound}
+					continue
+				}
+				if errors.Is(err, repo.ErrAlbumNotFound) {
+					results[i] = domain.ImportResult{Status: domain.ImportInvalid, Err: ErrAlbumNotFound}
+					continue
+				}
+				return err
+			}
+			batch = append(batch, songs[i])
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		ids, err := s.repo.ImportSongs(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		created := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			created[id] = true
+		}
+
+		for i, song := range songs {
+			if results[i].Status != "" {
+				continue
+			}
+			if !created[song.ID] {
+				results[i] = domain.ImportResult{Status: domain.ImportDuplicate, Err: ErrSongExists}
+				continue
+			}
+			results[i] = domain.ImportResult{Status: domain.ImportCreated, ID: song.ID}
+
+			if len(song.FeaturedArtistIDs) > 0 {
+				err = s.repo.SetFeaturedArtists(ctx, song.ID, song.FeaturedArtistIDs)
+				if err != nil {
+					return err
+				}
+			}
+			if song.AlbumID != uuid.Nil {
+				err = s.repo.AddAlbumTrack(ctx, song.AlbumID, song.ID)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		return s.repo.AddRevisions(ctx, ids)
+	})
+	if err != nil {
+		l.WithError(err).Error("error when import songs")
+		return nil, fmt.Errorf("error when import songs: %w", ErrCreateSong)
+	}
+
+	l.WithField("count", len(songs)).Info("import songs was successfully")
+	return results, nil
+}
+
+// importRefs keeps the artists and albums already looked up in an import batch.
+type importRefs struct {
+	artists map[string]*domain.Artist
+	albums  map[uuid.UUID]bool
+}
+
+// resolveImportRefs links the song to its artist like resolveArtist does
+// and checks that its album and featured artists exist.
+func (s *Service) resolveImportRefs(ctx context.Context, refs *importRefs, song *domain.Song) error {
+	artist, err := refs.artist(ctx, s, song.ArtistID, song.Group)
+	if err != nil {
+		return err
+	}
+	song.ArtistID = artist.ID
+	song.Group = artist.Name
+
+	for _, id := range song.FeaturedArtistIDs {
+		_, err = refs.artist(ctx, s, id, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if song.AlbumID != uuid.Nil && !refs.albums[song.AlbumID] {
+		_, err = s.repo.GetAlbum(ctx, &song.AlbumID)
+		if err != nil {
+			return err
+		}
+		refs.albums[song.AlbumID] = true
+	}
+	return nil
+}
+
+func (r *importRefs) artist(ctx context.Context, s *Service, id uuid.UUID, name string) (*domain.Artist, error) {
+	key := "name:" + strings.ToLower(strings.Join(strings.Fields(name), " "))
+	if id != uuid.Nil {
+		key = "id:" + id.String()
+	}
+	if artist, ok := r.artists[key]; ok {
+		return artist, nil
+	}
+
+	artist, err := s.findArtist(ctx, id, name)
+	if err != nil {
+		return nil, err
+	}
+	r.artists[key] = artist
+	return artist, nil
+}
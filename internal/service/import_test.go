@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_ImportSongs() {
+	ctx := context.Background()
+	artist := &domain.Artist{ID: uuid.New(), Name: "Muse"}
+	unknown := uuid.New()
+	songs := func() []domain.Song {
+		song := func(name string) domain.Song {
+			return domain.Song{
+				Group:       "muse",
+				Name:        name,
+				Text:        domain.SongText{{Type: domain.Verse, Text: "Paranoia is in bloom"}},
+				Link:        "https://example.com",
+				ReleaseDate: time.Date(2009, 9, 7, 0, 0, 0, 0, time.UTC),
+			}
+		}
+		invalid := song("Resistance")
+		invalid.ArtistID = unknown
+		return []domain.Song{song("Uprising"), invalid, song("Uprising ")}
+	}
+
+	tests := []struct {
+		name  string
+		wait  []domain.ImportStatus
+		err   error
+		calls func()
+	}{
+		{
+			name: "error import",
+			err:  fmt.Errorf("error when import songs: %w", ErrCreateSong),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(artist, nil)
+				s.repo.EXPECT().GetArtist(ctx, &unknown).Return(nil, repo.ErrArtistNotFound)
+				s.repo.EXPECT().ImportSongs(ctx, gomock.Len(2)).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "import songs",
+			wait: []domain.ImportStatus{domain.ImportCreated, domain.ImportInvalid, domain.ImportDuplicate},
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(artist, nil)
+				s.repo.EXPECT().GetArtist(ctx, &unknown).Return(nil, repo.ErrArtistNotFound)
+				s.repo.EXPECT().ImportSongs(ctx, gomock.Len(2)).DoAndReturn(
+					func(_ context.Context, batch []domain.Song) ([]uuid.UUID, error) {
+						s.Equal(artist.ID, batch[1].ArtistID)
+						s.Equal(artist.Name, batch[1].Group)
+						return []uuid.UUID{batch[0].ID}, nil
+					},
+				)
+				s.repo.EXPECT().AddRevisions(ctx, gomock.Len(1)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			batch := songs()
+			results, err := s.service.ImportSongs(ctx, batch)
+			s.Equal(tt.err, err)
+			if err != nil {
+				return
+			}
+
+			statuses := make([]domain.ImportStatus, 0, len(results))
+			for _, result := range results {
+				statuses = append(statuses, result.Status)
+			}
+			s.Equal(tt.wait, statuses)
+			s.Equal(batch[0].ID, results[0].ID)
+			s.Equal(ErrArtistNotFound, results[1].Err)
+			s.Equal(ErrSongExists, results[2].Err)
+		})
+	}
+}
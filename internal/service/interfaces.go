@@ -3,8 +3,10 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/songinfo"
 	"github.com/google/uuid"
 )
 
@@ -12,7 +14,52 @@ type Repository interface {
 	ExecTx(ctx context.Context, fn func(ctx context.Context) error) error
 	Create(ctx context.Context, song *domain.Song) (*uuid.UUID, error)
 	Update(ctx context.Context, song *domain.Song) error
-	Delete(ctx context.Context, id *uuid.UUID) error
-	GetTextSong(ctx context.Context, filter *domain.SongRequest) (domain.SongText, error)
-	GetSongs(ctx context.Context, filter *domain.SongRequest) ([]domain.Song, error)
+	Delete(ctx context.Context, id *uuid.UUID, version int) error
+	GetTextSong(ctx context.Context, filter *domain.SongRequest) (*domain.Song, error)
+	GetSongs(ctx context.Context, filter *domain.SongRequest) (*domain.SongList, error)
+	GetSong(ctx context.Context, id *uuid.UUID, lock bool) (*domain.Song, error)
+	Patch(ctx context.Context, id uuid.UUID, version int, patch *domain.SongPatch) (int, error)
+	GetTrash(ctx context.Context, filter *domain.TrashRequest) ([]domain.Song, error)
+	Restore(ctx context.Context, id *uuid.UUID) error
+	Purge(ctx context.Context, id *uuid.UUID) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	AddRevision(ctx context.Context, songID uuid.UUID) error
+	AddRevisions(ctx context.Context, songIDs []uuid.UUID) error
+	GetRevisions(ctx context.Context, songID *uuid.UUID) ([]domain.SongRevision, error)
+	GetRevision(ctx context.Context, songID *uuid.UUID, revision int) (*domain.SongRevision, error)
+	SearchSongs(ctx context.Context, filter *domain.SearchRequest) ([]domain.SearchResult, error)
+	Suggest(ctx context.Context, filter *domain.SuggestRequest) ([]domain.Suggestion, error)
+	ImportSongs(ctx context.Context, songs []domain.Song) ([]uuid.UUID, error)
+	ExportSongs(ctx context.Context, filter *domain.SongRequest, fn func(song *domain.Song) error) error
+
+	CreateArtist(ctx context.Context, artist *domain.Artist) (*uuid.UUID, error)
+	UpsertArtist(ctx context.Context, name string) (*domain.Artist, error)
+	UpdateArtist(ctx context.Context, patch *domain.ArtistPatch) error
+	DeleteArtist(ctx context.Context, id *uuid.UUID) error
+	GetArtist(ctx context.Context, id *uuid.UUID) (*domain.Artist, error)
+	GetArtists(ctx context.Context, filter *domain.ArtistRequest) ([]domain.Artist, error)
+	SetFeaturedArtists(ctx context.Context, songID uuid.UUID, artistIDs []uuid.UUID) error
+
+	CreateAlbum(ctx context.Context, album *domain.Album) (*uuid.UUID, error)
+	UpdateAlbum(ctx context.Context, patch *domain.AlbumPatch) error
+	DeleteAlbum(ctx context.Context, id *uuid.UUID) error
+	GetAlbum(ctx context.Context, id *uuid.UUID) (*domain.Album, error)
+	GetAlbums(ctx context.Context, filter *domain.AlbumRequest) ([]domain.Album, error)
+	SetAlbumTracks(ctx context.Context, albumID uuid.UUID, songIDs []uuid.UUID) error
+	AddAlbumTrack(ctx context.Context, albumID, songID uuid.UUID) error
+
+	CreateTranslation(ctx context.Context, t *domain.Translation) error
+	UpdateTranslation(ctx context.Context, t *domain.Translation) error
+	GetTranslation(ctx context.Context, songID uuid.UUID, lang string) (*domain.Translation, error)
+	GetTranslations(ctx context.Context, songID uuid.UUID) ([]domain.Translation, error)
+
+	ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, expiredBefore, leaseExpiredBefore time.Time) error
+	GetIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error)
+	CompleteIdempotencyKey(ctx context.Context, key string, owner uuid.UUID, status int, response []byte) error
+	DeleteIdempotencyKey(ctx context.Context, key string, owner uuid.UUID) error
+	PurgeIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type SongInfo interface {
+	GetSongDetail(ctx context.Context, group, song string) (*songinfo.SongDetail, error)
 }
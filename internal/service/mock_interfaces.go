@@ -7,10 +7,12 @@ package service
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	domain "github.com/Alina9496/library/internal/domain"
+	songinfo "github.com/Alina9496/library/internal/songinfo"
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	domain "github.com/Alina9496/library/internal/domain"
 )
 
 // MockRepository is a mock of Repository interface.
@@ -36,6 +38,62 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddAlbumTrack mocks base method.
+func (m *MockRepository) AddAlbumTrack(ctx context.Context, albumID, songID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAlbumTrack", ctx, albumID, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddAlbumTrack indicates an expected call of AddAlbumTrack.
+func (mr *MockRepositoryMockRecorder) AddAlbumTrack(ctx, albumID, songID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAlbumTrack", reflect.TypeOf((*MockRepository)(nil).AddAlbumTrack), ctx, albumID, songID)
+}
+
+// AddRevision mocks base method.
+func (m *MockRepository) AddRevision(ctx context.Context, songID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRevision", ctx, songID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRevision indicates an expected call of AddRevision.
+func (mr *MockRepositoryMockRecorder) AddRevision(ctx, songID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRevision", reflect.TypeOf((*MockRepository)(nil).AddRevision), ctx, songID)
+}
+
+// AddRevisions mocks base method.
+func (m *MockRepository) AddRevisions(ctx context.Context, songIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRevisions", ctx, songIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddRevisions indicates an expected call of AddRevisions.
+func (mr *MockRepositoryMockRecorder) AddRevisions(ctx, songIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRevisions", reflect.TypeOf((*MockRepository)(nil).AddRevisions), ctx, songIDs)
+}
+
+// CompleteIdempotencyKey mocks base method.
+func (m *MockRepository) CompleteIdempotencyKey(ctx context.Context, key string, owner uuid.UUID, status int, response []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteIdempotencyKey", ctx, key, owner, status, response)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteIdempotencyKey indicates an expected call of CompleteIdempotencyKey.
+func (mr *MockRepositoryMockRecorder) CompleteIdempotencyKey(ctx, key, owner, status, response interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteIdempotencyKey", reflect.TypeOf((*MockRepository)(nil).CompleteIdempotencyKey), ctx, key, owner, status, response)
+}
+
 // Create mocks base method.
 func (m *MockRepository) Create(ctx context.Context, song *domain.Song) (*uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -51,18 +109,104 @@ func (mr *MockRepositoryMockRecorder) Create(ctx, song interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRepository)(nil).Create), ctx, song)
 }
 
+// CreateAlbum mocks base method.
+func (m *MockRepository) CreateAlbum(ctx context.Context, album *domain.Album) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlbum", ctx, album)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlbum indicates an expected call of CreateAlbum.
+func (mr *MockRepositoryMockRecorder) CreateAlbum(ctx, album interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlbum", reflect.TypeOf((*MockRepository)(nil).CreateAlbum), ctx, album)
+}
+
+// CreateArtist mocks base method.
+func (m *MockRepository) CreateArtist(ctx context.Context, artist *domain.Artist) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArtist", ctx, artist)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArtist indicates an expected call of CreateArtist.
+func (mr *MockRepositoryMockRecorder) CreateArtist(ctx, artist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArtist", reflect.TypeOf((*MockRepository)(nil).CreateArtist), ctx, artist)
+}
+
+// CreateTranslation mocks base method.
+func (m *MockRepository) CreateTranslation(ctx context.Context, t *domain.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTranslation", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTranslation indicates an expected call of CreateTranslation.
+func (mr *MockRepositoryMockRecorder) CreateTranslation(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTranslation", reflect.TypeOf((*MockRepository)(nil).CreateTranslation), ctx, t)
+}
+
 // Delete mocks base method.
-func (m *MockRepository) Delete(ctx context.Context, id *uuid.UUID) error {
+func (m *MockRepository) Delete(ctx context.Context, id *uuid.UUID, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", ctx, id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRepositoryMockRecorder) Delete(ctx, id interface{}) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) Delete(ctx, id, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id, version)
+}
+
+// DeleteAlbum mocks base method.
+func (m *MockRepository) DeleteAlbum(ctx context.Context, id *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlbum", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlbum indicates an expected call of DeleteAlbum.
+func (mr *MockRepositoryMockRecorder) DeleteAlbum(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlbum", reflect.TypeOf((*MockRepository)(nil).DeleteAlbum), ctx, id)
+}
+
+// DeleteArtist mocks base method.
+func (m *MockRepository) DeleteArtist(ctx context.Context, id *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArtist", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArtist indicates an expected call of DeleteArtist.
+func (mr *MockRepositoryMockRecorder) DeleteArtist(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRepository)(nil).Delete), ctx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArtist", reflect.TypeOf((*MockRepository)(nil).DeleteArtist), ctx, id)
+}
+
+// DeleteIdempotencyKey mocks base method.
+func (m *MockRepository) DeleteIdempotencyKey(ctx context.Context, key string, owner uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyKey", ctx, key, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyKey indicates an expected call of DeleteIdempotencyKey.
+func (mr *MockRepositoryMockRecorder) DeleteIdempotencyKey(ctx, key, owner interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyKey", reflect.TypeOf((*MockRepository)(nil).DeleteIdempotencyKey), ctx, key, owner)
 }
 
 // ExecTx mocks base method.
@@ -79,11 +223,145 @@ func (mr *MockRepositoryMockRecorder) ExecTx(ctx, fn interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecTx", reflect.TypeOf((*MockRepository)(nil).ExecTx), ctx, fn)
 }
 
+// ExportSongs mocks base method.
+func (m *MockRepository) ExportSongs(ctx context.Context, filter *domain.SongRequest, fn func(*domain.Song) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportSongs", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportSongs indicates an expected call of ExportSongs.
+func (mr *MockRepositoryMockRecorder) ExportSongs(ctx, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportSongs", reflect.TypeOf((*MockRepository)(nil).ExportSongs), ctx, filter, fn)
+}
+
+// GetAlbum mocks base method.
+func (m *MockRepository) GetAlbum(ctx context.Context, id *uuid.UUID) (*domain.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbum", ctx, id)
+	ret0, _ := ret[0].(*domain.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbum indicates an expected call of GetAlbum.
+func (mr *MockRepositoryMockRecorder) GetAlbum(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbum", reflect.TypeOf((*MockRepository)(nil).GetAlbum), ctx, id)
+}
+
+// GetAlbums mocks base method.
+func (m *MockRepository) GetAlbums(ctx context.Context, filter *domain.AlbumRequest) ([]domain.Album, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlbums", ctx, filter)
+	ret0, _ := ret[0].([]domain.Album)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlbums indicates an expected call of GetAlbums.
+func (mr *MockRepositoryMockRecorder) GetAlbums(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlbums", reflect.TypeOf((*MockRepository)(nil).GetAlbums), ctx, filter)
+}
+
+// GetArtist mocks base method.
+func (m *MockRepository) GetArtist(ctx context.Context, id *uuid.UUID) (*domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtist", ctx, id)
+	ret0, _ := ret[0].(*domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtist indicates an expected call of GetArtist.
+func (mr *MockRepositoryMockRecorder) GetArtist(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtist", reflect.TypeOf((*MockRepository)(nil).GetArtist), ctx, id)
+}
+
+// GetArtists mocks base method.
+func (m *MockRepository) GetArtists(ctx context.Context, filter *domain.ArtistRequest) ([]domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArtists", ctx, filter)
+	ret0, _ := ret[0].([]domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArtists indicates an expected call of GetArtists.
+func (mr *MockRepositoryMockRecorder) GetArtists(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArtists", reflect.TypeOf((*MockRepository)(nil).GetArtists), ctx, filter)
+}
+
+// GetIdempotencyKey mocks base method.
+func (m *MockRepository) GetIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyKey", ctx, key)
+	ret0, _ := ret[0].(*domain.IdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyKey indicates an expected call of GetIdempotencyKey.
+func (mr *MockRepositoryMockRecorder) GetIdempotencyKey(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyKey", reflect.TypeOf((*MockRepository)(nil).GetIdempotencyKey), ctx, key)
+}
+
+// GetRevision mocks base method.
+func (m *MockRepository) GetRevision(ctx context.Context, songID *uuid.UUID, revision int) (*domain.SongRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", ctx, songID, revision)
+	ret0, _ := ret[0].(*domain.SongRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockRepositoryMockRecorder) GetRevision(ctx, songID, revision interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockRepository)(nil).GetRevision), ctx, songID, revision)
+}
+
+// GetRevisions mocks base method.
+func (m *MockRepository) GetRevisions(ctx context.Context, songID *uuid.UUID) ([]domain.SongRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", ctx, songID)
+	ret0, _ := ret[0].([]domain.SongRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockRepositoryMockRecorder) GetRevisions(ctx, songID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockRepository)(nil).GetRevisions), ctx, songID)
+}
+
+// GetSong mocks base method.
+func (m *MockRepository) GetSong(ctx context.Context, id *uuid.UUID, lock bool) (*domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSong", ctx, id, lock)
+	ret0, _ := ret[0].(*domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSong indicates an expected call of GetSong.
+func (mr *MockRepositoryMockRecorder) GetSong(ctx, id, lock interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSong", reflect.TypeOf((*MockRepository)(nil).GetSong), ctx, id, lock)
+}
+
 // GetSongs mocks base method.
-func (m *MockRepository) GetSongs(ctx context.Context, filter *domain.SongRequest) ([]domain.Song, error) {
+func (m *MockRepository) GetSongs(ctx context.Context, filter *domain.SongRequest) (*domain.SongList, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSongs", ctx, filter)
-	ret0, _ := ret[0].([]domain.Song)
+	ret0, _ := ret[0].(*domain.SongList)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -95,10 +373,10 @@ func (mr *MockRepositoryMockRecorder) GetSongs(ctx, filter interface{}) *gomock.
 }
 
 // GetTextSong mocks base method.
-func (m *MockRepository) GetTextSong(ctx context.Context, filter *domain.SongRequest) (domain.SongText, error) {
+func (m *MockRepository) GetTextSong(ctx context.Context, filter *domain.SongRequest) (*domain.Song, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetTextSong", ctx, filter)
-	ret0, _ := ret[0].(domain.SongText)
+	ret0, _ := ret[0].(*domain.Song)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -109,6 +387,211 @@ func (mr *MockRepositoryMockRecorder) GetTextSong(ctx, filter interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTextSong", reflect.TypeOf((*MockRepository)(nil).GetTextSong), ctx, filter)
 }
 
+// GetTranslation mocks base method.
+func (m *MockRepository) GetTranslation(ctx context.Context, songID uuid.UUID, lang string) (*domain.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslation", ctx, songID, lang)
+	ret0, _ := ret[0].(*domain.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslation indicates an expected call of GetTranslation.
+func (mr *MockRepositoryMockRecorder) GetTranslation(ctx, songID, lang interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslation", reflect.TypeOf((*MockRepository)(nil).GetTranslation), ctx, songID, lang)
+}
+
+// GetTranslations mocks base method.
+func (m *MockRepository) GetTranslations(ctx context.Context, songID uuid.UUID) ([]domain.Translation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTranslations", ctx, songID)
+	ret0, _ := ret[0].([]domain.Translation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTranslations indicates an expected call of GetTranslations.
+func (mr *MockRepositoryMockRecorder) GetTranslations(ctx, songID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTranslations", reflect.TypeOf((*MockRepository)(nil).GetTranslations), ctx, songID)
+}
+
+// GetTrash mocks base method.
+func (m *MockRepository) GetTrash(ctx context.Context, filter *domain.TrashRequest) ([]domain.Song, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", ctx, filter)
+	ret0, _ := ret[0].([]domain.Song)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockRepositoryMockRecorder) GetTrash(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockRepository)(nil).GetTrash), ctx, filter)
+}
+
+// ImportSongs mocks base method.
+func (m *MockRepository) ImportSongs(ctx context.Context, songs []domain.Song) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportSongs", ctx, songs)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportSongs indicates an expected call of ImportSongs.
+func (mr *MockRepositoryMockRecorder) ImportSongs(ctx, songs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportSongs", reflect.TypeOf((*MockRepository)(nil).ImportSongs), ctx, songs)
+}
+
+// Patch mocks base method.
+func (m *MockRepository) Patch(ctx context.Context, id uuid.UUID, version int, patch *domain.SongPatch) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Patch", ctx, id, version, patch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Patch indicates an expected call of Patch.
+func (mr *MockRepositoryMockRecorder) Patch(ctx, id, version, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Patch", reflect.TypeOf((*MockRepository)(nil).Patch), ctx, id, version, patch)
+}
+
+// Purge mocks base method.
+func (m *MockRepository) Purge(ctx context.Context, id *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockRepositoryMockRecorder) Purge(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockRepository)(nil).Purge), ctx, id)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockRepositoryMockRecorder) PurgeDeletedBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockRepository)(nil).PurgeDeletedBefore), ctx, before)
+}
+
+// PurgeIdempotencyKeysBefore mocks base method.
+func (m *MockRepository) PurgeIdempotencyKeysBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeIdempotencyKeysBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeIdempotencyKeysBefore indicates an expected call of PurgeIdempotencyKeysBefore.
+func (mr *MockRepositoryMockRecorder) PurgeIdempotencyKeysBefore(ctx, before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeIdempotencyKeysBefore", reflect.TypeOf((*MockRepository)(nil).PurgeIdempotencyKeysBefore), ctx, before)
+}
+
+// ReserveIdempotencyKey mocks base method.
+func (m *MockRepository) ReserveIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey, expiredBefore, leaseExpiredBefore time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveIdempotencyKey", ctx, key, expiredBefore, leaseExpiredBefore)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveIdempotencyKey indicates an expected call of ReserveIdempotencyKey.
+func (mr *MockRepositoryMockRecorder) ReserveIdempotencyKey(ctx, key, expiredBefore, leaseExpiredBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveIdempotencyKey", reflect.TypeOf((*MockRepository)(nil).ReserveIdempotencyKey), ctx, key, expiredBefore, leaseExpiredBefore)
+}
+
+// Restore mocks base method.
+func (m *MockRepository) Restore(ctx context.Context, id *uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockRepositoryMockRecorder) Restore(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockRepository)(nil).Restore), ctx, id)
+}
+
+// SearchSongs mocks base method.
+func (m *MockRepository) SearchSongs(ctx context.Context, filter *domain.SearchRequest) ([]domain.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSongs", ctx, filter)
+	ret0, _ := ret[0].([]domain.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSongs indicates an expected call of SearchSongs.
+func (mr *MockRepositoryMockRecorder) SearchSongs(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSongs", reflect.TypeOf((*MockRepository)(nil).SearchSongs), ctx, filter)
+}
+
+// SetAlbumTracks mocks base method.
+func (m *MockRepository) SetAlbumTracks(ctx context.Context, albumID uuid.UUID, songIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAlbumTracks", ctx, albumID, songIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAlbumTracks indicates an expected call of SetAlbumTracks.
+func (mr *MockRepositoryMockRecorder) SetAlbumTracks(ctx, albumID, songIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAlbumTracks", reflect.TypeOf((*MockRepository)(nil).SetAlbumTracks), ctx, albumID, songIDs)
+}
+
+// SetFeaturedArtists mocks base method.
+func (m *MockRepository) SetFeaturedArtists(ctx context.Context, songID uuid.UUID, artistIDs []uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFeaturedArtists", ctx, songID, artistIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFeaturedArtists indicates an expected call of SetFeaturedArtists.
+func (mr *MockRepositoryMockRecorder) SetFeaturedArtists(ctx, songID, artistIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFeaturedArtists", reflect.TypeOf((*MockRepository)(nil).SetFeaturedArtists), ctx, songID, artistIDs)
+}
+
+// Suggest mocks base method.
+func (m *MockRepository) Suggest(ctx context.Context, filter *domain.SuggestRequest) ([]domain.Suggestion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Suggest", ctx, filter)
+	ret0, _ := ret[0].([]domain.Suggestion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Suggest indicates an expected call of Suggest.
+func (mr *MockRepositoryMockRecorder) Suggest(ctx, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Suggest", reflect.TypeOf((*MockRepository)(nil).Suggest), ctx, filter)
+}
+
 // Update mocks base method.
 func (m *MockRepository) Update(ctx context.Context, song *domain.Song) error {
 	m.ctrl.T.Helper()
@@ -122,3 +605,98 @@ func (mr *MockRepositoryMockRecorder) Update(ctx, song interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockRepository)(nil).Update), ctx, song)
 }
+
+// UpdateAlbum mocks base method.
+func (m *MockRepository) UpdateAlbum(ctx context.Context, patch *domain.AlbumPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlbum", ctx, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlbum indicates an expected call of UpdateAlbum.
+func (mr *MockRepositoryMockRecorder) UpdateAlbum(ctx, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlbum", reflect.TypeOf((*MockRepository)(nil).UpdateAlbum), ctx, patch)
+}
+
+// UpdateArtist mocks base method.
+func (m *MockRepository) UpdateArtist(ctx context.Context, patch *domain.ArtistPatch) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateArtist", ctx, patch)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateArtist indicates an expected call of UpdateArtist.
+func (mr *MockRepositoryMockRecorder) UpdateArtist(ctx, patch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArtist", reflect.TypeOf((*MockRepository)(nil).UpdateArtist), ctx, patch)
+}
+
+// UpdateTranslation mocks base method.
+func (m *MockRepository) UpdateTranslation(ctx context.Context, t *domain.Translation) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTranslation", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTranslation indicates an expected call of UpdateTranslation.
+func (mr *MockRepositoryMockRecorder) UpdateTranslation(ctx, t interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTranslation", reflect.TypeOf((*MockRepository)(nil).UpdateTranslation), ctx, t)
+}
+
+// UpsertArtist mocks base method.
+func (m *MockRepository) UpsertArtist(ctx context.Context, name string) (*domain.Artist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertArtist", ctx, name)
+	ret0, _ := ret[0].(*domain.Artist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertArtist indicates an expected call of UpsertArtist.
+func (mr *MockRepositoryMockRecorder) UpsertArtist(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertArtist", reflect.TypeOf((*MockRepository)(nil).UpsertArtist), ctx, name)
+}
+
+// MockSongInfo is a mock of SongInfo interface.
+type MockSongInfo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSongInfoMockRecorder
+}
+
+// MockSongInfoMockRecorder is the mock recorder for MockSongInfo.
+type MockSongInfoMockRecorder struct {
+	mock *MockSongInfo
+}
+
+// NewMockSongInfo creates a new mock instance.
+func NewMockSongInfo(ctrl *gomock.Controller) *MockSongInfo {
+	mock := &MockSongInfo{ctrl: ctrl}
+	mock.recorder = &MockSongInfoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSongInfo) EXPECT() *MockSongInfoMockRecorder {
+	return m.recorder
+}
+
+// GetSongDetail mocks base method.
+func (m *MockSongInfo) GetSongDetail(ctx context.Context, group, song string) (*songinfo.SongDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSongDetail", ctx, group, song)
+	ret0, _ := ret[0].(*songinfo.SongDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSongDetail indicates an expected call of GetSongDetail.
+func (mr *MockSongInfoMockRecorder) GetSongDetail(ctx, group, song interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSongDetail", reflect.TypeOf((*MockSongInfo)(nil).GetSongDetail), ctx, group, song)
+}
@@ -0,0 +1,47 @@
+package service
+
+import "time"
+
+// Option -.
+type Option func(*Service)
+
+// TrashRetention sets how long deleted songs stay in the trash,
+// zero keeps them until they are purged by hand.
+func TrashRetention(retention time.Duration) Option {
+	return func(s *Service) {
+		s.trashRetention = retention
+	}
+}
+
+// IdempotencyTTL sets how long responses to requests with an idempotency key
+// are replayed, zero disables the replay.
+func IdempotencyTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.idempotencyTTL = ttl
+	}
+}
+
+// IdempotencyLease sets how long a request in progress holds its idempotency
+// key, after that a retry can take the key over. It should be longer than
+// the handling of a request.
+func IdempotencyLease(lease time.Duration) Option {
+	return func(s *Service) {
+		s.idempotencyLease = lease
+	}
+}
+
+// SearchSimilarity sets the least word similarity, from 0 to 1, of the group
+// and the name of a song to the filter of the song list.
+func SearchSimilarity(similarity float64) Option {
+	return func(s *Service) {
+		s.searchSimilarity = similarity
+	}
+}
+
+// SuggestTTL sets how long the suggestions of a type-ahead query are cached,
+// zero disables the cache.
+func SuggestTTL(ttl time.Duration) Option {
+	return func(s *Service) {
+		s.suggestTTL = ttl
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_Patch() {
+	ctx := context.Background()
+	id := uuid.New()
+	gaga := &domain.Artist{ID: uuid.New(), Name: "Lady Gaga"}
+	muse := &domain.Artist{ID: uuid.New(), Name: "Muse"}
+	current := func() *domain.Song {
+		return &domain.Song{
+			ID:                id,
+			ArtistID:          gaga.ID,
+			Group:             "Lady Gaga",
+			Name:              "Poker Face",
+			Link:              "link",
+			ReleaseDate:       time.Date(2008, 9, 23, 0, 0, 0, 0, time.UTC),
+			Text:              domain.SongText{{Type: domain.Verse, Text: "one"}},
+			FeaturedArtistIDs: []uuid.UUID{},
+			Version:           1,
+		}
+	}
+	rename := func(song *domain.Song) error {
+		song.Name = "Alejandro"
+		song.Text[0].Text = "changed"
+		return nil
+	}
+	name, text := "Alejandro", domain.SongText{{Type: domain.Verse, Text: "changed"}}
+	musePatch := &domain.SongPatch{ArtistID: &muse.ID, Group: &muse.Name}
+	existingID := uuid.New()
+	annotated := func() *domain.Song {
+		song := current()
+		song.Text = domain.SongText{
+			{Type: domain.Verse, Text: "one", Timing: []domain.TimedLine{{Start: 1000}}, Chords: []domain.Chord{{Name: "Am"}}},
+			{Type: domain.Chorus, Text: "two", Timing: []domain.TimedLine{{Start: 2000}}, Chords: []domain.Chord{{Name: "G"}}},
+		}
+		return song
+	}
+	annotatedText := domain.SongText{
+		{Type: domain.Verse, Text: "one", Timing: []domain.TimedLine{{Start: 1000}}, Chords: []domain.Chord{{Name: "Am"}}},
+		{Type: domain.Chorus, Text: "changed"},
+	}
+	importedText := domain.SongText{
+		{Type: domain.Verse, Text: "one", Timing: []domain.TimedLine{{Start: 1000}}, Chords: []domain.Chord{{Name: "C"}}},
+		{Type: domain.Chorus, Text: "changed", Chords: []domain.Chord{{Name: "D"}}},
+	}
+
+	tests := []struct {
+		name    string
+		id      *uuid.UUID
+		version int
+		apply   func(song *domain.Song) error
+		wait    int
+		err     error
+		calls   func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			apply: rename,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name:  "song not found",
+			id:    &id,
+			apply: rename,
+			err:   ErrSongNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(nil, repo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "invalid patch",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				return errors.ErrUnsupported
+			},
+			err: fmt.Errorf("%w: %w", ErrInvalidPatch, errors.ErrUnsupported),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+			},
+		},
+		{
+			name:    "version mismatch",
+			id:      &id,
+			version: 2,
+			apply:   rename,
+			err:     ErrVersionMismatch,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+			},
+		},
+		{
+			name: "nothing changed",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				return nil
+			},
+			wait: 1,
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+			},
+		},
+		{
+			name:    "only changed fields are written",
+			id:      &id,
+			version: 1,
+			apply:   rename,
+			wait:    2,
+			err:     nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+				s.repo.EXPECT().Patch(ctx, id, 1, &domain.SongPatch{Name: &name, Text: &text}).Return(2, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name: "timing and chords are kept only for unchanged sections",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				song.Text = domain.SongText{
+					{Type: domain.Verse, Text: "one"},
+					{Type: domain.Chorus, Text: "changed"},
+				}
+				return nil
+			},
+			wait: 2,
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(annotated(), nil)
+				s.repo.EXPECT().Patch(ctx, id, 0, &domain.SongPatch{Text: &annotatedText}).Return(2, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name: "section changed in place loses timing and chords",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				song.Text[1].Text = "changed"
+				return nil
+			},
+			wait: 2,
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(annotated(), nil)
+				s.repo.EXPECT().Patch(ctx, id, 0, &domain.SongPatch{Text: &annotatedText}).Return(2, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name: "imported chords are kept for changed sections",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				song.Text = domain.SongText{
+					{Type: domain.Verse, Text: "one", Chords: []domain.Chord{{Name: "C"}}},
+					{Type: domain.Chorus, Text: "changed", Chords: []domain.Chord{{Name: "D"}}},
+				}
+				return nil
+			},
+			wait: 2,
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(annotated(), nil)
+				s.repo.EXPECT().Patch(ctx, id, 0, &domain.SongPatch{Text: &importedText}).Return(2, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name:  "song with the new name exists",
+			id:    &id,
+			apply: rename,
+			err:   &SongExistsError{ID: existingID},
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+				s.repo.EXPECT().Patch(ctx, id, 0, &domain.SongPatch{Name: &name, Text: &text}).Return(0, repo.ErrSongExists)
+				s.repo.EXPECT().GetTextSong(ctx, &domain.SongRequest{Group: "Lady Gaga", Name: "Alejandro"}).
+					Return(&domain.Song{ID: existingID}, nil)
+			},
+		},
+		{
+			name: "new group links the song to another artist",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				song.Group = "muse"
+				return nil
+			},
+			wait: 2,
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(muse, nil)
+				s.repo.EXPECT().Patch(ctx, id, 0, musePatch).Return(2, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name: "error patch",
+			id:   &id,
+			apply: func(song *domain.Song) error {
+				song.FeaturedArtistIDs = []uuid.UUID{muse.ID}
+				return nil
+			},
+			err: fmt.Errorf("error when patch: %w", ErrUpdateSong),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(current(), nil)
+				s.repo.EXPECT().Patch(ctx, id, 0, &domain.SongPatch{FeaturedArtistIDs: &[]uuid.UUID{muse.ID}}).Return(2, nil)
+				s.repo.EXPECT().SetFeaturedArtists(ctx, id, []uuid.UUID{muse.ID}).Return(errors.ErrUnsupported)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			version, err := s.service.Patch(ctx, tt.id, tt.version, tt.apply)
+			s.Equal(tt.wait, version)
+			s.Equal(tt.err, err)
+		})
+	}
+}
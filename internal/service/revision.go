@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+// GetRevisions returns the revisions of the song, the latest first.
+// Every song has at least one revision, so an empty list means the song doesn't exist.
+func (s *Service) GetRevisions(ctx context.Context, id *uuid.UUID) ([]domain.SongRevision, error) {
+	l := s.log.WithField("service_method", "GetRevisions")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return nil, ErrIDIsNil
+	}
+
+	revisions, err := s.repo.GetRevisions(ctx, id)
+	if err != nil {
+		l.WithError(err).Error("error when getRevisions")
+		return nil, fmt.Errorf("error when getRevisions: %w", ErrGetRevision)
+	}
+	if len(revisions) == 0 {
+		return nil, ErrSongNotFound
+	}
+
+	l.Info("the revisions was found successfully")
+	return revisions, nil
+}
+
+func (s *Service) GetRevision(ctx context.Context, id *uuid.UUID, revision int) (*domain.SongRevision, error) {
+	l := s.log.WithField("service_method", "GetRevision")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return nil, ErrIDIsNil
+	}
+
+	rev, err := s.repo.GetRevision(ctx, id, revision)
+	if err != nil {
+		if errors.Is(err, repo.ErrRevisionNotFound) {
+			return nil, ErrRevisionNotFound
+		}
+		l.WithError(err).Error("error when getRevision")
+		return nil, fmt.Errorf("error when getRevision: %w", ErrGetRevision)
+	}
+
+	l.Info("the revision was found successfully")
+	return rev, nil
+}
+
+// RollbackRevision restores the song to the revision. The rollback is
+// an ordinary update, so it is recorded as a new revision.
+func (s *Service) RollbackRevision(ctx context.Context, id *uuid.UUID, revision int) error {
+	rev, err := s.GetRevision(ctx, id, revision)
+	if err != nil {
+		return err
+	}
+
+	return s.Update(ctx, &rev.Song)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_GetRevisions() {
+	ctx := context.Background()
+	id := uuid.New()
+	revisions := []domain.SongRevision{
+		{Revision: 2, Song: domain.Song{ID: id, Name: "name"}},
+		{Revision: 1, Song: domain.Song{ID: id, Name: "old name"}},
+	}
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		want  []domain.SongRevision
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			want:  nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "song without revisions doesn't exist",
+			id:   &id,
+			want: nil,
+			err:  ErrSongNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetRevisions(ctx, &id).Return([]domain.SongRevision{}, nil)
+			},
+		},
+		{
+			name: "error get revisions",
+			id:   &id,
+			want: nil,
+			err:  fmt.Errorf("error when getRevisions: %w", ErrGetRevision),
+			calls: func() {
+				s.repo.EXPECT().GetRevisions(ctx, &id).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "revisions were found",
+			id:   &id,
+			want: revisions,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().GetRevisions(ctx, &id).Return(revisions, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.GetRevisions(ctx, tt.id)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_RollbackRevision() {
+	ctx := context.Background()
+	id := uuid.New()
+	artist := &domain.Artist{ID: uuid.New(), Name: "Lady Gaga"}
+	rev := &domain.SongRevision{
+		Revision:  1,
+		CreatedAt: time.Now(),
+		Song: domain.Song{
+			ID:          id,
+			ArtistID:    artist.ID,
+			Group:       "Lady Gaga",
+			Name:        "Poker Face",
+			Link:        "link",
+			ReleaseDate: time.Now(),
+			Text:        domain.SongText{{Type: "verse", Text: "text"}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		id       *uuid.UUID
+		revision int
+		err      error
+		calls    func()
+	}{
+		{
+			name:     "id equal nil",
+			id:       nil,
+			revision: 1,
+			err:      ErrIDIsNil,
+			calls:    func() {},
+		},
+		{
+			name:     "revision not found",
+			id:       &id,
+			revision: 5,
+			err:      ErrRevisionNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetRevision(ctx, &id, 5).Return(nil, repo.ErrRevisionNotFound)
+			},
+		},
+		{
+			name:     "song was rolled back",
+			id:       &id,
+			revision: 1,
+			err:      nil,
+			calls: func() {
+				s.repo.EXPECT().GetRevision(ctx, &id, 1).Return(rev, nil)
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetArtist(ctx, &artist.ID).Return(artist, nil)
+				s.repo.EXPECT().Update(ctx, &rev.Song).Return(nil)
+				s.repo.EXPECT().SetFeaturedArtists(ctx, id, rev.Song.FeaturedArtistIDs).Return(nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.RollbackRevision(ctx, tt.id, tt.revision)
+			s.Equal(tt.err, err)
+		})
+	}
+}
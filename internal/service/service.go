@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Alina9496/library/internal/domain"
 	"github.com/Alina9496/library/internal/repo"
@@ -11,19 +13,51 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	_defaultTrashRetention   = 30 * 24 * time.Hour
+	_defaultIdempotencyTTL   = 24 * time.Hour
+	_defaultIdempotencyLease = time.Minute
+	_defaultSimilarity       = 0.3
+	_defaultSuggestTTL       = 10 * time.Second
+)
+
 type Service struct {
 	repo Repository
+	info SongInfo
 	log  *logger.Logger
+
+	trashRetention   time.Duration
+	idempotencyTTL   time.Duration
+	idempotencyLease time.Duration
+	searchSimilarity float64
+	suggestTTL       time.Duration
+	suggestions      *suggestCache
 }
 
 func New(
 	r Repository,
+	info SongInfo,
 	log *logger.Logger,
+	opts ...Option,
 ) *Service {
-	return &Service{
-		repo: r,
-		log:  log,
+	s := &Service{
+		repo:             r,
+		info:             info,
+		log:              log,
+		trashRetention:   _defaultTrashRetention,
+		idempotencyTTL:   _defaultIdempotencyTTL,
+		idempotencyLease: _defaultIdempotencyLease,
+		searchSimilarity: _defaultSimilarity,
+		suggestTTL:       _defaultSuggestTTL,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.suggestions = newSuggestCache(s.suggestTTL)
+
+	return s
 }
 
 func (s *Service) Create(ctx context.Context, song *domain.Song) (*uuid.UUID, error) {
@@ -33,8 +67,49 @@ func (s *Service) Create(ctx context.Context, song *domain.Song) (*uuid.UUID, er
 		return nil, ErrSongIsNil
 	}
 
-	id, err := s.repo.Create(ctx, song)
+	err := s.prepareSong(ctx, l, song)
+	if err != nil {
+		return nil, err
+	}
+
+	var id *uuid.UUID
+	err = s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		err := s.resolveArtist(ctx, song)
+		if err != nil {
+			return err
+		}
+
+		id, err = s.repo.Create(ctx, song)
+		if err != nil {
+			return err
+		}
+
+		if len(song.FeaturedArtistIDs) > 0 {
+			err = s.repo.SetFeaturedArtists(ctx, *id, song.FeaturedArtistIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		if song.AlbumID != uuid.Nil {
+			err = s.repo.AddAlbumTrack(ctx, song.AlbumID, *id)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.repo.AddRevision(ctx, *id)
+	})
 	if err != nil {
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return nil, ErrArtistNotFound
+		}
+		if errors.Is(err, repo.ErrAlbumNotFound) {
+			return nil, ErrAlbumNotFound
+		}
+		if errors.Is(err, repo.ErrSongExists) {
+			return nil, s.songExists(ctx, song.Group, song.Name)
+		}
 		l.WithError(err).Error("error when create")
 		return nil, fmt.Errorf("error when create: %w", ErrCreateSong)
 	}
@@ -43,6 +118,43 @@ func (s *Service) Create(ctx context.Context, song *domain.Song) (*uuid.UUID, er
 	return id, nil
 }
 
+// prepareSong fills the details of a new song the client has not sent
+// from its album and from the song info provider.
+func (s *Service) prepareSong(ctx context.Context, l *logger.Logger, song *domain.Song) error {
+	if song.AlbumID != uuid.Nil {
+		err := s.fillFromAlbum(ctx, song)
+		if err != nil {
+			if errors.Is(err, repo.ErrAlbumNotFound) {
+				return ErrAlbumNotFound
+			}
+			l.WithError(err).Error("error when get album")
+			return fmt.Errorf("error when get album: %w", ErrGetAlbum)
+		}
+	}
+
+	if song.IsIncomplete() {
+		if song.Group == "" {
+			artist, err := s.repo.GetArtist(ctx, &song.ArtistID)
+			if err != nil {
+				if errors.Is(err, repo.ErrArtistNotFound) {
+					return ErrArtistNotFound
+				}
+				l.WithError(err).Error("error when get artist")
+				return fmt.Errorf("error when get artist: %w", ErrGetArtist)
+			}
+			song.Group = artist.Name
+		}
+
+		err := s.fillSongDetail(ctx, song)
+		if err != nil {
+			l.WithError(err).Error("error when get song info")
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (s *Service) Update(ctx context.Context, song *domain.Song) error {
 	l := s.log.WithField("service_method", "Update")
 	if song == nil {
@@ -50,11 +162,34 @@ func (s *Service) Update(ctx context.Context, song *domain.Song) error {
 		return ErrSongIsNil
 	}
 
-	err := s.repo.Update(ctx, song)
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		err := s.resolveArtist(ctx, song)
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.Update(ctx, song)
+		if err != nil {
+			return err
+		}
+
+		err = s.repo.SetFeaturedArtists(ctx, song.ID, song.FeaturedArtistIDs)
+		if err != nil {
+			return err
+		}
+
+		return s.repo.AddRevision(ctx, song.ID)
+	})
 	if err != nil {
 		if errors.Is(err, repo.ErrSongNotFound) {
 			return ErrSongNotFound
 		}
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return ErrArtistNotFound
+		}
+		if errors.Is(err, repo.ErrSongExists) {
+			return s.songExists(ctx, song.Group, song.Name)
+		}
 		l.WithError(err).Error("error when update")
 		return fmt.Errorf("error when update: %w", ErrUpdateSong)
 	}
@@ -63,18 +198,163 @@ func (s *Service) Update(ctx context.Context, song *domain.Song) error {
 	return nil
 }
 
-func (s *Service) Delete(ctx context.Context, id *uuid.UUID) error {
+// Patch changes the song by apply, which receives a copy of the current song
+// with the lyrics stripped of timing and chords, and returns the new version
+// of the song. The sections apply leaves unchanged get their timing and chords
+// back unless apply set new ones. A non-zero version must match the
+// stored one. Only the changed fields are written, a change of the group
+// without the artist ID links the song to the artist with that name.
+func (s *Service) Patch(ctx context.Context, id *uuid.UUID, version int, apply func(song *domain.Song) error) (int, error) {
+	l := s.log.WithField("service_method", "Patch")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return 0, ErrIDIsNil
+	}
+
+	var (
+		errApply   error
+		newVersion int
+		patched    domain.Song
+	)
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetSong(ctx, id, true)
+		if err != nil {
+			return err
+		}
+		if version > 0 && current.Version != version {
+			return repo.ErrVersionMismatch
+		}
+		newVersion = current.Version
+
+		patched = *current
+		patched.Text = current.Text.WithoutAnnotations()
+		patched.FeaturedArtistIDs = append([]uuid.UUID(nil), current.FeaturedArtistIDs...)
+		errApply = apply(&patched)
+		if errApply != nil {
+			return errApply
+		}
+		patched.Text.KeepAnnotations(current.Text)
+
+		if patched.Group != current.Group && patched.ArtistID == current.ArtistID {
+			patched.ArtistID = uuid.Nil
+		}
+		if patched.Group != current.Group || patched.ArtistID != current.ArtistID {
+			err = s.resolveArtist(ctx, &patched)
+			if err != nil {
+				return err
+			}
+		}
+
+		patch := current.Diff(&patched)
+		if patch.IsEmpty() {
+			return nil
+		}
+
+		newVersion, err = s.repo.Patch(ctx, *id, version, patch)
+		if err != nil {
+			return err
+		}
+
+		if patch.FeaturedArtistIDs != nil {
+			err = s.repo.SetFeaturedArtists(ctx, *id, patched.FeaturedArtistIDs)
+			if err != nil {
+				return err
+			}
+		}
+
+		return s.repo.AddRevision(ctx, *id)
+	})
+	if err != nil {
+		if errApply != nil {
+			return 0, fmt.Errorf("%w: %w", ErrInvalidPatch, errApply)
+		}
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return 0, ErrSongNotFound
+		}
+		if errors.Is(err, repo.ErrVersionMismatch) {
+			return 0, ErrVersionMismatch
+		}
+		if errors.Is(err, repo.ErrArtistNotFound) {
+			return 0, ErrArtistNotFound
+		}
+		if errors.Is(err, repo.ErrSongExists) {
+			return 0, s.songExists(ctx, patched.Group, patched.Name)
+		}
+		l.WithError(err).Error("error when patch")
+		return 0, fmt.Errorf("error when patch: %w", ErrUpdateSong)
+	}
+
+	l.Info("patch song was successfully")
+	return newVersion, nil
+}
+
+// songExists returns the error with the ID of the live song with the same
+// group and name. It is called after the transaction is rolled back.
+func (s *Service) songExists(ctx context.Context, group, name string) error {
+	song, err := s.repo.GetTextSong(ctx, &domain.SongRequest{Group: group, Name: name})
+	if err != nil {
+		return ErrSongExists
+	}
+	return &SongExistsError{ID: song.ID}
+}
+
+// resolveArtist links the song to the artist by ID or by the group name,
+// creating the artist when it is new. The group takes the artist spelling.
+func (s *Service) resolveArtist(ctx context.Context, song *domain.Song) error {
+	artist, err := s.findArtist(ctx, song.ArtistID, song.Group)
+	if err != nil {
+		return err
+	}
+
+	song.ArtistID = artist.ID
+	song.Group = artist.Name
+	return nil
+}
+
+func (s *Service) findArtist(ctx context.Context, id uuid.UUID, name string) (*domain.Artist, error) {
+	if id != uuid.Nil {
+		return s.repo.GetArtist(ctx, &id)
+	}
+	return s.repo.UpsertArtist(ctx, name)
+}
+
+// fillFromAlbum defaults the release date and the artist of the song from its album.
+func (s *Service) fillFromAlbum(ctx context.Context, song *domain.Song) error {
+	if !song.ReleaseDate.IsZero() && (song.Group != "" || song.ArtistID != uuid.Nil) {
+		return nil
+	}
+
+	album, err := s.repo.GetAlbum(ctx, &song.AlbumID)
+	if err != nil {
+		return err
+	}
+
+	if song.ReleaseDate.IsZero() {
+		song.ReleaseDate = album.ReleaseDate
+	}
+	if song.Group == "" && song.ArtistID == uuid.Nil {
+		song.ArtistID = album.ArtistID
+		song.Group = album.Artist
+	}
+	return nil
+}
+
+// Delete moves the song to the trash. A non-zero version must match the stored one.
+func (s *Service) Delete(ctx context.Context, id *uuid.UUID, version int) error {
 	l := s.log.WithField("service_method", "Delete")
 	if id == nil {
 		l.Debug(ErrIDIsNil.Error())
 		return ErrIDIsNil
 	}
 
-	err := s.repo.Delete(ctx, id)
+	err := s.repo.Delete(ctx, id, version)
 	if err != nil {
 		if errors.Is(err, repo.ErrSongNotFound) {
 			return ErrSongNotFound
 		}
+		if errors.Is(err, repo.ErrVersionMismatch) {
+			return ErrVersionMismatch
+		}
 		l.WithError(err).Error("error when delete")
 		return fmt.Errorf("error when delete: %w", ErrDeleteSong)
 	}
@@ -83,44 +363,124 @@ func (s *Service) Delete(ctx context.Context, id *uuid.UUID) error {
 	return nil
 }
 
-func (s *Service) GetTextSong(ctx context.Context, filter *domain.SongRequest) (string, error) {
+// GetTextSong returns the sections and lines of the song at the filter text address.
+func (s *Service) GetTextSong(ctx context.Context, filter *domain.SongRequest) (*domain.SongTextResult, error) {
 	l := s.log.WithField("service_method", "GetTextSong")
 	if filter == nil {
 		l.Debug(ErrFilterIsNil.Error())
-		return "", ErrFilterIsNil
+		return nil, ErrFilterIsNil
 	}
 
-	songText, err := s.repo.GetTextSong(ctx, filter)
+	song, err := s.repo.GetTextSong(ctx, filter)
 	if err != nil {
 		if errors.Is(err, repo.ErrSongNotFound) {
-			return "", ErrSongNotFound
+			return nil, ErrSongNotFound
 		}
 		l.WithError(err).Error("error when getTextSong")
-		return "", fmt.Errorf("error when getTextSong: %w", ErrGetSong)
+		return nil, fmt.Errorf("error when getTextSong: %w", ErrGetSong)
+	}
+
+	result, err := selectText(song.Text, filter.Text)
+	if err != nil {
+		return nil, err
 	}
+	result.Version = song.Version
 
-	count := 0
-	for _, val := range songText {
-		if val.Type == domain.Verse {
-			count++
-			if count == filter.Offset {
-				l.Info("the song text was found successfully")
-				return val.Text, nil
+	if filter.Lang != "" {
+		translation, err := s.repo.GetTranslation(ctx, song.ID, filter.Lang)
+		if err != nil {
+			if errors.Is(err, repo.ErrTranslationNotFound) {
+				return nil, ErrTranslationNotFound
 			}
+			l.WithError(err).Error("error when getTranslation")
+			return nil, fmt.Errorf("error when getTranslation: %w", ErrGetTranslation)
 		}
+
+		translated, err := selectText(translation.Aligned(song.Text), filter.Text)
+		if err != nil {
+			return nil, err
+		}
+		result.Translation = translated.Text
 	}
 
-	return "", ErrSongNotFound
+	l.Info("the song text was found successfully")
+	return result, nil
 }
 
-func (s *Service) GetSongs(ctx context.Context, filter *domain.SongRequest) ([]domain.Song, error) {
+// selectText returns the part of the lyrics at the address.
+func selectText(text domain.SongText, addr domain.TextAddress) (*domain.SongTextResult, error) {
+	sections := make([]string, 0, len(text))
+	for _, item := range text {
+		if addr.Section == "" || item.Type == addr.Section {
+			sections = append(sections, item.Text)
+		}
+	}
+
+	from, to := addr.From, addr.To
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = from
+	}
+	if from > to || to > len(sections) {
+		return nil, ErrSectionOutOfRange
+	}
+
+	result := &domain.SongTextResult{
+		Text:    strings.Join(sections[from-1:to], "\n\n"),
+		Total:   len(sections),
+		HasNext: to < len(sections),
+	}
+	if addr.LineFrom == 0 {
+		return result, nil
+	}
+
+	if from != to {
+		return nil, ErrLineOutOfRange
+	}
+	lineTo := addr.LineTo
+	if lineTo == 0 {
+		lineTo = addr.LineFrom
+	}
+	lines := strings.Split(result.Text, "\n")
+	if addr.LineFrom > lineTo || lineTo > len(lines) {
+		return nil, ErrLineOutOfRange
+	}
+	result.Text = strings.Join(lines[addr.LineFrom-1:lineTo], "\n")
+	return result, nil
+}
+
+// GetSong returns the song with its lyrics and featured artists.
+func (s *Service) GetSong(ctx context.Context, id *uuid.UUID) (*domain.Song, error) {
+	l := s.log.WithField("service_method", "GetSong")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return nil, ErrIDIsNil
+	}
+
+	song, err := s.repo.GetSong(ctx, id, false)
+	if err != nil {
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return nil, ErrSongNotFound
+		}
+		l.WithError(err).Error("error when getSong")
+		return nil, fmt.Errorf("error when getSong: %w", ErrGetSong)
+	}
+
+	l.Info("the song was found successfully")
+	return song, nil
+}
+
+func (s *Service) GetSongs(ctx context.Context, filter *domain.SongRequest) (*domain.SongList, error) {
 	l := s.log.WithField("service_method", "GetSongs")
 	if filter == nil {
 		l.Debug(ErrFilterIsNil.Error())
 		return nil, ErrFilterIsNil
 	}
 
-	songs, err := s.repo.GetSongs(ctx, filter)
+	filter.Similarity = s.searchSimilarity
+	list, err := s.repo.GetSongs(ctx, filter)
 	if err != nil {
 		if errors.Is(err, repo.ErrSongNotFound) {
 			return nil, ErrSongNotFound
@@ -130,5 +490,22 @@ func (s *Service) GetSongs(ctx context.Context, filter *domain.SongRequest) ([]d
 	}
 
 	l.Info("the songs was found successfully")
-	return songs, nil
+	return list, nil
+}
+
+func (s *Service) SearchSongs(ctx context.Context, filter *domain.SearchRequest) ([]domain.SearchResult, error) {
+	l := s.log.WithField("service_method", "SearchSongs")
+	if filter == nil {
+		l.Debug(ErrFilterIsNil.Error())
+		return nil, ErrFilterIsNil
+	}
+
+	results, err := s.repo.SearchSongs(ctx, filter)
+	if err != nil {
+		l.WithError(err).Error("error when searchSongs")
+		return nil, fmt.Errorf("error when searchSongs: %w", ErrSearchSongs)
+	}
+
+	l.Info("the songs search was successfully")
+	return results, nil
 }
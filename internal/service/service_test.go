@@ -9,6 +9,7 @@ import (
 
 	"github.com/Alina9496/library/internal/domain"
 	"github.com/Alina9496/library/internal/repo"
+	"github.com/Alina9496/library/internal/songinfo"
 	"github.com/Alina9496/tool/pkg/logger"
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -18,34 +19,104 @@ import (
 type ServiceSuite struct {
 	suite.Suite
 	repo    *MockRepository
+	info    *MockSongInfo
 	service Service
 }
 
 func (s *ServiceSuite) SetupTest() {
 	ctrl := gomock.NewController(s.T())
 	s.repo = NewMockRepository(ctrl)
-	s.service = *New(s.repo, logger.New(""))
+	s.info = NewMockSongInfo(ctrl)
+	s.service = *New(s.repo, s.info, logger.New(""))
 }
 
 func TestServiceSuite(t *testing.T) {
 	suite.Run(t, new(ServiceSuite))
 }
 
+func (s *ServiceSuite) expectTx(ctx context.Context) {
+	s.repo.EXPECT().ExecTx(ctx, gomock.Any()).DoAndReturn(
+		func(ctx context.Context, fn func(ctx context.Context) error) error {
+			return fn(ctx)
+		},
+	)
+}
+
 func (s *ServiceSuite) Test_Create() {
 	ctx := context.Background()
 	id := uuid.New()
-	song := &domain.Song{
-		Group:       "group",
-		Name:        "name",
-		Link:        "link",
-		ReleaseDate: time.Now(),
-		Text: domain.SongText{
-			{
-				Type: "verse",
-				Text: "text",
+	artist := &domain.Artist{ID: uuid.New(), Name: "Group"}
+	newSong := func() *domain.Song {
+		return &domain.Song{
+			Group:       "group",
+			Name:        "name",
+			Link:        "link",
+			ReleaseDate: time.Now(),
+			Text: domain.SongText{
+				{
+					Type: "verse",
+					Text: "text",
+				},
 			},
+		}
+	}
+	songNotCreated, songWithoutArtist, song, duplicate := newSong(), newSong(), newSong(), newSong()
+	existingID := uuid.New()
+	featuredID := uuid.New()
+	featured := &domain.Song{
+		Group:             "group",
+		Name:              "name",
+		Link:              "link",
+		ReleaseDate:       time.Now(),
+		Text:              domain.SongText{{Type: "verse", Text: "text"}},
+		FeaturedArtistIDs: []uuid.UUID{featuredID},
+	}
+	muse := &domain.Artist{ID: uuid.New(), Name: "Muse"}
+	releaseDate, _ := time.Parse(time.DateOnly, "2006-07-16")
+	partial := &domain.Song{
+		Group: "muse",
+		Name:  "Supermassive Black Hole",
+	}
+	enriched := &domain.Song{
+		Group:       "Muse",
+		ArtistID:    muse.ID,
+		Name:        "Supermassive Black Hole",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+		ReleaseDate: releaseDate,
+		Text: domain.SongText{
+			{Type: "verse", Text: "Ooh baby, don't you know I suffer?"},
+			{Type: "verse", Text: "Ooh\nYou caught me under false pretenses"},
 		},
 	}
+	byArtistID := &domain.Song{
+		ArtistID: muse.ID,
+		Name:     "Supermassive Black Hole",
+	}
+	album := &domain.Album{
+		ID:          uuid.New(),
+		ArtistID:    muse.ID,
+		Artist:      "Muse",
+		Title:       "Black Holes and Revelations",
+		ReleaseDate: releaseDate,
+	}
+	newAlbumSong := func() *domain.Song {
+		return &domain.Song{
+			AlbumID: album.ID,
+			Name:    "Supermassive Black Hole",
+			Link:    "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+			Text:    domain.SongText{{Type: "verse", Text: "text"}},
+		}
+	}
+	albumNotFound, albumSong := newAlbumSong(), newAlbumSong()
+	albumSongCreated := newAlbumSong()
+	albumSongCreated.ArtistID = muse.ID
+	albumSongCreated.Group = "Muse"
+	albumSongCreated.ReleaseDate = releaseDate
+	detail := &songinfo.SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?\n\nOoh\nYou caught me under false pretenses\n",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	}
 	tests := []struct {
 		name  string
 		ctx   context.Context
@@ -65,11 +136,38 @@ func (s *ServiceSuite) Test_Create() {
 		{
 			name: "error create song",
 			ctx:  ctx,
-			song: song,
+			song: songNotCreated,
+			want: nil,
+			err:  fmt.Errorf("error when create: %w", ErrCreateSong),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
+				s.repo.EXPECT().Create(ctx, songNotCreated).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "song already exists",
+			ctx:  ctx,
+			song: duplicate,
+			want: nil,
+			err:  &SongExistsError{ID: existingID},
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
+				s.repo.EXPECT().Create(ctx, duplicate).Return(nil, repo.ErrSongExists)
+				s.repo.EXPECT().GetTextSong(ctx, &domain.SongRequest{Group: "Group", Name: "name"}).
+					Return(&domain.Song{ID: existingID}, nil)
+			},
+		},
+		{
+			name: "error upsert artist",
+			ctx:  ctx,
+			song: songWithoutArtist,
 			want: nil,
 			err:  fmt.Errorf("error when create: %w", ErrCreateSong),
 			calls: func() {
-				s.repo.EXPECT().Create(ctx, song).Return(nil, errors.ErrUnsupported)
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(nil, errors.ErrUnsupported)
 			},
 		},
 		{
@@ -79,7 +177,92 @@ func (s *ServiceSuite) Test_Create() {
 			want: &id,
 			err:  nil,
 			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
 				s.repo.EXPECT().Create(ctx, song).Return(&id, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name: "featured artist not found",
+			ctx:  ctx,
+			song: featured,
+			want: nil,
+			err:  ErrArtistNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
+				s.repo.EXPECT().Create(ctx, featured).Return(&id, nil)
+				s.repo.EXPECT().SetFeaturedArtists(ctx, id, []uuid.UUID{featuredID}).Return(repo.ErrArtistNotFound)
+			},
+		},
+		{
+			name: "song info not found",
+			ctx:  ctx,
+			song: &domain.Song{Group: "Muse", Name: "Supermassive Black Hole"},
+			want: nil,
+			err:  ErrSongInfoNotFound,
+			calls: func() {
+				s.info.EXPECT().GetSongDetail(ctx, "Muse", "Supermassive Black Hole").Return(nil, songinfo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "error get song info",
+			ctx:  ctx,
+			song: &domain.Song{Group: "Muse", Name: "Supermassive Black Hole"},
+			want: nil,
+			err:  fmt.Errorf("error when get song info: %w", ErrGetSongInfo),
+			calls: func() {
+				s.info.EXPECT().GetSongDetail(ctx, "Muse", "Supermassive Black Hole").Return(nil, songinfo.ErrBadResponse)
+			},
+		},
+		{
+			name: "song was enriched and created",
+			ctx:  ctx,
+			song: partial,
+			want: &id,
+			err:  nil,
+			calls: func() {
+				s.info.EXPECT().GetSongDetail(ctx, "muse", "Supermassive Black Hole").Return(detail, nil)
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "muse").Return(muse, nil)
+				s.repo.EXPECT().Create(ctx, enriched).Return(&id, nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
+			},
+		},
+		{
+			name: "artist of the song not found",
+			ctx:  ctx,
+			song: byArtistID,
+			want: nil,
+			err:  ErrArtistNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetArtist(ctx, &muse.ID).Return(nil, repo.ErrArtistNotFound)
+			},
+		},
+		{
+			name: "album not found",
+			ctx:  ctx,
+			song: albumNotFound,
+			want: nil,
+			err:  ErrAlbumNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetAlbum(ctx, &album.ID).Return(nil, repo.ErrAlbumNotFound)
+			},
+		},
+		{
+			name: "song takes release date and artist from the album",
+			ctx:  ctx,
+			song: albumSong,
+			want: &id,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().GetAlbum(ctx, &album.ID).Return(album, nil)
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetArtist(ctx, &muse.ID).Return(muse, nil)
+				s.repo.EXPECT().Create(ctx, albumSongCreated).Return(&id, nil)
+				s.repo.EXPECT().AddAlbumTrack(ctx, album.ID, id).Return(nil)
+				s.repo.EXPECT().AddRevision(ctx, id).Return(nil)
 			},
 		},
 	}
@@ -95,18 +278,23 @@ func (s *ServiceSuite) Test_Create() {
 
 func (s *ServiceSuite) Test_Update() {
 	ctx := context.Background()
-	song := &domain.Song{
-		Group:       "group",
-		Name:        "name",
-		Link:        "link",
-		ReleaseDate: time.Now(),
-		Text: domain.SongText{
-			{
-				Type: "verse",
-				Text: "text",
+	artist := &domain.Artist{ID: uuid.New(), Name: "Group"}
+	newSong := func() *domain.Song {
+		return &domain.Song{
+			ID:          uuid.New(),
+			Group:       "group",
+			Name:        "name",
+			Link:        "link",
+			ReleaseDate: time.Now(),
+			Text: domain.SongText{
+				{
+					Type: "verse",
+					Text: "text",
+				},
 			},
-		},
+		}
 	}
+	songNotFound, songNotUpdated, revisionNotSaved, song := newSong(), newSong(), newSong(), newSong()
 	tests := []struct {
 		name  string
 		ctx   context.Context
@@ -124,19 +312,36 @@ func (s *ServiceSuite) Test_Update() {
 		{
 			name: "song not found",
 			ctx:  ctx,
-			song: song,
-			err:  fmt.Errorf("error when update: %w", ErrSongNotFound),
+			song: songNotFound,
+			err:  ErrSongNotFound,
 			calls: func() {
-				s.repo.EXPECT().Update(ctx, song).Return(repo.ErrSongNotFound)
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
+				s.repo.EXPECT().Update(ctx, songNotFound).Return(repo.ErrSongNotFound)
 			},
 		},
 		{
 			name: "error update song",
 			ctx:  ctx,
-			song: song,
+			song: songNotUpdated,
+			err:  fmt.Errorf("error when update: %w", ErrUpdateSong),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
+				s.repo.EXPECT().Update(ctx, songNotUpdated).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "error save revision",
+			ctx:  ctx,
+			song: revisionNotSaved,
 			err:  fmt.Errorf("error when update: %w", ErrUpdateSong),
 			calls: func() {
-				s.repo.EXPECT().Update(ctx, song).Return(errors.ErrUnsupported)
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
+				s.repo.EXPECT().Update(ctx, revisionNotSaved).Return(nil)
+				s.repo.EXPECT().SetFeaturedArtists(ctx, revisionNotSaved.ID, revisionNotSaved.FeaturedArtistIDs).Return(nil)
+				s.repo.EXPECT().AddRevision(ctx, revisionNotSaved.ID).Return(errors.ErrUnsupported)
 			},
 		},
 		{
@@ -145,7 +350,11 @@ func (s *ServiceSuite) Test_Update() {
 			song: song,
 			err:  nil,
 			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().UpsertArtist(ctx, "group").Return(artist, nil)
 				s.repo.EXPECT().Update(ctx, song).Return(nil)
+				s.repo.EXPECT().SetFeaturedArtists(ctx, song.ID, song.FeaturedArtistIDs).Return(nil)
+				s.repo.EXPECT().AddRevision(ctx, song.ID).Return(nil)
 			},
 		},
 	}
@@ -162,17 +371,18 @@ func (s *ServiceSuite) Test_Delete() {
 	ctx := context.Background()
 	id := uuid.New()
 	tests := []struct {
-		name  string
-		ctx   context.Context
-		id    *uuid.UUID
-		err   error
-		calls func()
+		name    string
+		ctx     context.Context
+		id      *uuid.UUID
+		version int
+		err     error
+		calls   func()
 	}{
 		{
 			name:  "id equal nil",
 			ctx:   ctx,
 			id:    nil,
-			err:   ErrSongIsNil,
+			err:   ErrIDIsNil,
 			calls: func() {},
 		},
 		{
@@ -181,7 +391,7 @@ func (s *ServiceSuite) Test_Delete() {
 			id:   &id,
 			err:  ErrSongNotFound,
 			calls: func() {
-				s.repo.EXPECT().Delete(ctx, &id).Return(repo.ErrSongNotFound)
+				s.repo.EXPECT().Delete(ctx, &id, 0).Return(repo.ErrSongNotFound)
 			},
 		},
 		{
@@ -190,7 +400,17 @@ func (s *ServiceSuite) Test_Delete() {
 			id:   &id,
 			err:  fmt.Errorf("error when delete: %w", ErrDeleteSong),
 			calls: func() {
-				s.repo.EXPECT().Delete(ctx, id).Return(errors.ErrUnsupported)
+				s.repo.EXPECT().Delete(ctx, &id, 0).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name:    "version mismatch",
+			ctx:     ctx,
+			id:      &id,
+			version: 2,
+			err:     ErrVersionMismatch,
+			calls: func() {
+				s.repo.EXPECT().Delete(ctx, &id, 2).Return(repo.ErrVersionMismatch)
 			},
 		},
 		{
@@ -199,14 +419,14 @@ func (s *ServiceSuite) Test_Delete() {
 			id:   &id,
 			err:  nil,
 			calls: func() {
-				s.repo.EXPECT().Delete(ctx, id).Return(nil)
+				s.repo.EXPECT().Delete(ctx, &id, 0).Return(nil)
 			},
 		},
 	}
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
 			tt.calls()
-			err := s.service.Delete(tt.ctx, &id)
+			err := s.service.Delete(tt.ctx, tt.id, tt.version)
 			s.Equal(tt.err, err)
 		})
 	}
@@ -215,26 +435,30 @@ func (s *ServiceSuite) Test_Delete() {
 func (s *ServiceSuite) Test_GetTextSong() {
 	ctx := context.Background()
 	filter := &domain.SongRequest{
-		Group:  "group",
-		Name:   "name",
-		Offset: 2,
+		Group: "group",
+		Name:  "name",
+		Text:  domain.TextAddress{Section: domain.Verse, From: 2},
 	}
-	filterNotFound := &domain.SongRequest{
-		Group:  "group",
-		Name:   "name",
-		Offset: 5,
+	song := &domain.Song{
+		Text: domain.SongText{
+			{Type: "verse", Text: "text1"},
+			{Type: "chorus", Text: "text2"},
+			{Type: "verse", Text: "text3"},
+		},
+		Version: 4,
 	}
-	songText := domain.SongText{
-		{Type: "verse", Text: "text1"},
-		{Type: "chorus", Text: "text2"},
-		{Type: "verse", Text: "text3"},
+	translated := &domain.SongRequest{
+		Group: "group",
+		Name:  "name",
+		Text:  domain.TextAddress{From: 2, To: 3},
+		Lang:  "ru",
 	}
 
 	tests := []struct {
 		name   string
 		ctx    context.Context
 		filter *domain.SongRequest
-		wait   string
+		wait   *domain.SongTextResult
 		err    error
 		calls  func()
 	}{
@@ -242,7 +466,7 @@ func (s *ServiceSuite) Test_GetTextSong() {
 			name:   "filter equal nil",
 			ctx:    ctx,
 			filter: nil,
-			wait:   "",
+			wait:   nil,
 			err:    ErrFilterIsNil,
 			calls:  func() {},
 		},
@@ -250,7 +474,7 @@ func (s *ServiceSuite) Test_GetTextSong() {
 			name:   "song not found",
 			ctx:    ctx,
 			filter: filter,
-			wait:   "",
+			wait:   nil,
 			err:    ErrSongNotFound,
 			calls: func() {
 				s.repo.EXPECT().GetTextSong(ctx, filter).Return(nil, repo.ErrSongNotFound)
@@ -260,7 +484,7 @@ func (s *ServiceSuite) Test_GetTextSong() {
 			name:   "error get song",
 			ctx:    ctx,
 			filter: filter,
-			wait:   "",
+			wait:   nil,
 			err:    fmt.Errorf("error when getTextSong: %w", ErrGetSong),
 			calls: func() {
 				s.repo.EXPECT().GetTextSong(ctx, filter).Return(nil, errors.ErrUnsupported)
@@ -270,28 +494,197 @@ func (s *ServiceSuite) Test_GetTextSong() {
 			name:   "get song",
 			ctx:    ctx,
 			filter: filter,
-			wait:   "text3",
+			wait:   &domain.SongTextResult{Text: "text3", Version: 4, Total: 2},
 			err:    nil,
 			calls: func() {
-				s.repo.EXPECT().GetTextSong(ctx, filter).Return(songText, nil)
+				s.repo.EXPECT().GetTextSong(ctx, filter).Return(song, nil)
 			},
 		},
 		{
-			name:   "verse not found",
+			name:   "verse out of range",
 			ctx:    ctx,
-			filter: filterNotFound,
-			wait:   "",
-			err:    ErrSongNotFound,
+			filter: filter,
+			wait:   nil,
+			err:    ErrSectionOutOfRange,
+			calls: func() {
+				s.repo.EXPECT().GetTextSong(ctx, filter).Return(&domain.Song{Text: song.Text[:2]}, nil)
+			},
+		},
+		{
+			name:   "translation not found",
+			ctx:    ctx,
+			filter: translated,
+			wait:   nil,
+			err:    ErrTranslationNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetTextSong(ctx, translated).Return(song, nil)
+				s.repo.EXPECT().GetTranslation(ctx, song.ID, "ru").Return(nil, repo.ErrTranslationNotFound)
+			},
+		},
+		{
+			name:   "translation side by side",
+			ctx:    ctx,
+			filter: translated,
+			wait:   &domain.SongTextResult{Text: "text2\n\ntext3", Translation: "текст2\n\ntext3", Version: 4, Total: 3},
+			err:    nil,
+			calls: func() {
+				s.repo.EXPECT().GetTextSong(ctx, translated).Return(song, nil)
+				s.repo.EXPECT().GetTranslation(ctx, song.ID, "ru").Return(&domain.Translation{
+					Lang: "ru",
+					Text: domain.SongText{
+						{Type: "verse", Text: "текст1"},
+						{Type: "chorus", Text: "текст2"},
+					},
+				}, nil)
+			},
+		},
+		{
+			name:   "section changed since translated",
+			ctx:    ctx,
+			filter: translated,
+			wait:   &domain.SongTextResult{Text: "text2\n\ntext3", Translation: "text2\n\ntext3", Version: 4, Total: 3},
+			err:    nil,
 			calls: func() {
-				s.repo.EXPECT().GetTextSong(ctx, filterNotFound).Return(songText, nil)
+				s.repo.EXPECT().GetTextSong(ctx, translated).Return(song, nil)
+				s.repo.EXPECT().GetTranslation(ctx, song.ID, "ru").Return(&domain.Translation{
+					Lang: "ru",
+					Text: domain.SongText{
+						{Type: "verse", Text: "текст1"},
+						{Type: "chorus", Text: "текст2"},
+					},
+					Sources: domain.SongText{
+						{Type: "verse", Text: "text1"},
+						{Type: "chorus", Text: "old text2"},
+					}.SourceHashes(),
+				}, nil)
 			},
 		},
 	}
 	for _, tt := range tests {
 		s.T().Run(tt.name, func(t *testing.T) {
 			tt.calls()
-			text, err := s.service.GetTextSong(tt.ctx, tt.filter)
-			s.Equal(tt.wait, text)
+			result, err := s.service.GetTextSong(tt.ctx, tt.filter)
+			s.Equal(tt.wait, result)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_selectText() {
+	text := domain.SongText{
+		{Type: domain.Intro, Text: "intro"},
+		{Type: domain.Verse, Text: "verse 1.1\nverse 1.2\nverse 1.3"},
+		{Type: domain.Chorus, Text: "chorus"},
+		{Type: domain.Verse, Text: "verse 2"},
+	}
+
+	tests := []struct {
+		name string
+		addr domain.TextAddress
+		want *domain.SongTextResult
+		err  error
+	}{
+		{
+			name: "first section overall",
+			addr: domain.TextAddress{},
+			want: &domain.SongTextResult{Text: "intro", Total: 4, HasNext: true},
+		},
+		{
+			name: "last verse",
+			addr: domain.TextAddress{Section: domain.Verse, From: 2},
+			want: &domain.SongTextResult{Text: "verse 2", Total: 2},
+		},
+		{
+			name: "range across sections",
+			addr: domain.TextAddress{From: 3, To: 4},
+			want: &domain.SongTextResult{Text: "chorus\n\nverse 2", Total: 4},
+		},
+		{
+			name: "lines of a verse",
+			addr: domain.TextAddress{Section: domain.Verse, From: 1, LineFrom: 2, LineTo: 3},
+			want: &domain.SongTextResult{Text: "verse 1.2\nverse 1.3", Total: 2, HasNext: true},
+		},
+		{
+			name: "section out of range",
+			addr: domain.TextAddress{Section: domain.Chorus, From: 2},
+			err:  ErrSectionOutOfRange,
+		},
+		{
+			name: "no sections of the type",
+			addr: domain.TextAddress{Section: domain.Bridge},
+			err:  ErrSectionOutOfRange,
+		},
+		{
+			name: "line out of range",
+			addr: domain.TextAddress{From: 1, LineFrom: 2},
+			err:  ErrLineOutOfRange,
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			got, err := selectText(text, tt.addr)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_GetSong() {
+	ctx := context.Background()
+	id := uuid.New()
+	song := &domain.Song{
+		ID:   id,
+		Name: "Poker Face",
+		Text: domain.SongText{{Type: domain.Verse, Text: "text"}},
+	}
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		want  *domain.Song
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			want:  nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "song not found",
+			id:   &id,
+			want: nil,
+			err:  ErrSongNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetSong(ctx, &id, false).Return(nil, repo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "error get song",
+			id:   &id,
+			want: nil,
+			err:  fmt.Errorf("error when getSong: %w", ErrGetSong),
+			calls: func() {
+				s.repo.EXPECT().GetSong(ctx, &id, false).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "get song",
+			id:   &id,
+			want: song,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().GetSong(ctx, &id, false).Return(song, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.GetSong(ctx, tt.id)
+			s.Equal(tt.want, got)
 			s.Equal(tt.err, err)
 		})
 	}
@@ -308,20 +701,23 @@ func (s *ServiceSuite) Test_GetSongs() {
 		Limit:       2,
 		Offset:      2,
 	}
-	res := []domain.Song{
-		{
-			Name:        "name",
-			Group:       "group",
-			Link:        "https://lyrsense.com",
-			ReleaseDate: date,
+	res := &domain.SongList{
+		Songs: []domain.Song{
+			{
+				Name:        "name",
+				Group:       "group",
+				Link:        "https://lyrsense.com",
+				ReleaseDate: date,
+			},
 		},
+		Total: 3,
 	}
 
 	tests := []struct {
 		name   string
 		ctx    context.Context
 		filter *domain.SongRequest
-		wait   []domain.Song
+		wait   *domain.SongList
 		err    error
 		calls  func()
 	}{
@@ -360,7 +756,12 @@ func (s *ServiceSuite) Test_GetSongs() {
 			wait:   res,
 			err:    nil,
 			calls: func() {
-				s.repo.EXPECT().GetSongs(ctx, filter).Return(res, nil)
+				s.repo.EXPECT().GetSongs(ctx, filter).DoAndReturn(
+					func(_ context.Context, filter *domain.SongRequest) (*domain.SongList, error) {
+						s.Equal(_defaultSimilarity, filter.Similarity)
+						return res, nil
+					},
+				)
 			},
 		},
 	}
@@ -373,3 +774,68 @@ func (s *ServiceSuite) Test_GetSongs() {
 		})
 	}
 }
+
+func (s *ServiceSuite) Test_SearchSongs() {
+	ctx := context.Background()
+	filter := &domain.SearchRequest{
+		Query: "poker face",
+		Limit: 10,
+	}
+	res := []domain.SearchResult{
+		{
+			ID:           uuid.New(),
+			Name:         "Poker Face",
+			Group:        "Lady Gaga",
+			Rank:         0.5,
+			SectionIndex: 6,
+			SectionType:  domain.Chorus,
+			Snippet:      "No, he can't read my <mark>poker</mark> <mark>face</mark>",
+		},
+	}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		filter *domain.SearchRequest
+		wait   []domain.SearchResult
+		err    error
+		calls  func()
+	}{
+		{
+			name:   "filter equal nil",
+			ctx:    ctx,
+			filter: nil,
+			wait:   nil,
+			err:    ErrFilterIsNil,
+			calls:  func() {},
+		},
+		{
+			name:   "error search songs",
+			ctx:    ctx,
+			filter: filter,
+			wait:   nil,
+			err:    fmt.Errorf("error when searchSongs: %w", ErrSearchSongs),
+			calls: func() {
+				s.repo.EXPECT().SearchSongs(ctx, filter).Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name:   "search songs",
+			ctx:    ctx,
+			filter: filter,
+			wait:   res,
+			err:    nil,
+			calls: func() {
+				s.repo.EXPECT().SearchSongs(ctx, filter).Return(res, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.SearchSongs(tt.ctx, tt.filter)
+			s.Equal(tt.wait, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
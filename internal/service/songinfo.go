@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/lyrics"
+	"github.com/Alina9496/library/internal/songinfo"
+)
+
+const songInfoDateLayout = "02.01.2006"
+
+// fillSongDetail requests the song details from the provider
+// and fills only the fields the client has not sent.
+func (s *Service) fillSongDetail(ctx context.Context, song *domain.Song) error {
+	detail, err := s.info.GetSongDetail(ctx, song.Group, song.Name)
+	if err != nil {
+		if errors.Is(err, songinfo.ErrSongNotFound) {
+			return ErrSongInfoNotFound
+		}
+		return fmt.Errorf("error when get song info: %w", ErrGetSongInfo)
+	}
+
+	if song.ReleaseDate.IsZero() {
+		song.ReleaseDate, err = parseSongInfoDate(detail.ReleaseDate)
+		if err != nil {
+			return fmt.Errorf("error when parse release date: %w", ErrGetSongInfo)
+		}
+	}
+	if song.Link == "" {
+		song.Link = detail.Link
+	}
+	if len(song.Text) == 0 {
+		song.Text = lyrics.Split(detail.Text)
+	}
+
+	return nil
+}
+
+func parseSongInfoDate(date string) (time.Time, error) {
+	parsed, err := time.Parse(songInfoDateLayout, date)
+	if err != nil {
+		return time.Parse(time.DateOnly, date)
+	}
+	return parsed, nil
+}
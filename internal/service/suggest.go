@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+)
+
+// suggestCacheSize bounds the number of the cached queries.
+const suggestCacheSize = 10000
+
+// Suggest returns the song names and the groups for a type-ahead. The
+// suggestions of a query are cached for the suggest TTL, so that the same
+// prefix typed again doesn't reach the database.
+func (s *Service) Suggest(ctx context.Context, filter *domain.SuggestRequest) ([]domain.Suggestion, error) {
+	l := s.log.WithField("service_method", "Suggest")
+	if filter == nil {
+		l.Debug(ErrFilterIsNil.Error())
+		return nil, ErrFilterIsNil
+	}
+
+	key := strconv.Itoa(filter.Limit) + ":" + strings.ToLower(strings.Join(strings.Fields(filter.Query), " "))
+	if suggestions, ok := s.suggestions.get(key, time.Now()); ok {
+		return suggestions, nil
+	}
+
+	filter.Similarity = s.searchSimilarity
+	suggestions, err := s.repo.Suggest(ctx, filter)
+	if err != nil {
+		l.WithError(err).Error("error when suggest")
+		return nil, fmt.Errorf("error when suggest: %w", ErrSearchSongs)
+	}
+
+	s.suggestions.put(key, suggestions, time.Now())
+	return suggestions, nil
+}
+
+// suggestCache keeps the suggestions of the recent queries in memory,
+// a zero TTL disables it.
+type suggestCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]suggestEntry
+}
+
+type suggestEntry struct {
+	suggestions []domain.Suggestion
+	expires     time.Time
+}
+
+func newSuggestCache(ttl time.Duration) *suggestCache {
+	return &suggestCache{
+		ttl:     ttl,
+		entries: make(map[string]suggestEntry),
+	}
+}
+
+func (c *suggestCache) get(key string, now time.Time) ([]domain.Suggestion, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		return nil, false
+	}
+	return entry.suggestions, true
+}
+
+// put caches the suggestions of the query. When the cache is full the
+// expired entries are dropped, or all of them if none has expired.
+func (c *suggestCache) put(key string, suggestions []domain.Suggestion, now time.Time) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= suggestCacheSize {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= suggestCacheSize {
+			c.entries = make(map[string]suggestEntry)
+		}
+	}
+	c.entries[key] = suggestEntry{suggestions: suggestions, expires: now.Add(c.ttl)}
+}
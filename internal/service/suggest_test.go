@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_Suggest() {
+	ctx := context.Background()
+	res := []domain.Suggestion{
+		{Kind: domain.SuggestGroup, ID: uuid.New(), Text: "Queen"},
+		{Kind: domain.SuggestSong, ID: uuid.New(), Text: "Bohemian Rhapsody", Group: "Queen"},
+	}
+
+	tests := []struct {
+		name   string
+		filter *domain.SuggestRequest
+		wait   []domain.Suggestion
+		err    error
+		calls  func()
+	}{
+		{
+			name:   "filter equal nil",
+			filter: nil,
+			err:    ErrFilterIsNil,
+			calls:  func() {},
+		},
+		{
+			name:   "error suggest",
+			filter: &domain.SuggestRequest{Query: "que", Limit: 5},
+			err:    fmt.Errorf("error when suggest: %w", ErrSearchSongs),
+			calls: func() {
+				s.repo.EXPECT().Suggest(ctx, &domain.SuggestRequest{Query: "que", Limit: 5, Similarity: _defaultSimilarity}).
+					Return(nil, errors.ErrUnsupported)
+			},
+		},
+		{
+			name:   "suggest",
+			filter: &domain.SuggestRequest{Query: "queen", Limit: 5},
+			wait:   res,
+			calls: func() {
+				s.repo.EXPECT().Suggest(ctx, &domain.SuggestRequest{Query: "queen", Limit: 5, Similarity: _defaultSimilarity}).
+					Return(res, nil)
+			},
+		},
+		{
+			name:   "cached suggest",
+			filter: &domain.SuggestRequest{Query: " Queen ", Limit: 5},
+			wait:   res,
+			calls:  func() {},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			suggestions, err := s.service.Suggest(ctx, tt.filter)
+			s.Equal(tt.wait, suggestions)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_suggestCache() {
+	now := time.Now()
+	res := []domain.Suggestion{{Kind: domain.SuggestGroup, ID: uuid.New(), Text: "Queen"}}
+
+	cache := newSuggestCache(time.Second)
+	cache.put("5:queen", res, now)
+
+	got, ok := cache.get("5:queen", now.Add(time.Second/2))
+	s.True(ok)
+	s.Equal(res, got)
+
+	_, ok = cache.get("5:queen", now.Add(time.Second))
+	s.False(ok)
+
+	_, ok = cache.get("10:queen", now)
+	s.False(ok)
+
+	disabled := newSuggestCache(0)
+	disabled.put("5:queen", res, now)
+	_, ok = disabled.get("5:queen", now)
+	s.False(ok)
+}
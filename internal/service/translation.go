@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+// CreateTranslation adds the translation of the song to another language.
+// The translation must have a section for each section of the lyrics.
+func (s *Service) CreateTranslation(ctx context.Context, t *domain.Translation) error {
+	l := s.log.WithField("service_method", "CreateTranslation")
+	if t == nil {
+		l.Debug(ErrTranslationIsNil.Error())
+		return ErrTranslationIsNil
+	}
+
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		err := s.alignTranslation(ctx, t)
+		if err != nil {
+			return err
+		}
+		return s.repo.CreateTranslation(ctx, t)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrTranslationMismatch) {
+			return err
+		}
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return ErrSongNotFound
+		}
+		if errors.Is(err, repo.ErrTranslationExists) {
+			return ErrTranslationExists
+		}
+		l.WithError(err).Error("error when create translation")
+		return fmt.Errorf("error when create translation: %w", ErrCreateTranslation)
+	}
+
+	l.WithField("lang", t.Lang).Info("create translation was successfully")
+	return nil
+}
+
+// UpdateTranslation replaces the text of the translation to the language.
+func (s *Service) UpdateTranslation(ctx context.Context, t *domain.Translation) error {
+	l := s.log.WithField("service_method", "UpdateTranslation")
+	if t == nil {
+		l.Debug(ErrTranslationIsNil.Error())
+		return ErrTranslationIsNil
+	}
+
+	err := s.repo.ExecTx(ctx, func(ctx context.Context) error {
+		err := s.alignTranslation(ctx, t)
+		if err != nil {
+			return err
+		}
+		return s.repo.UpdateTranslation(ctx, t)
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrTranslationMismatch) {
+			return err
+		}
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return ErrSongNotFound
+		}
+		if errors.Is(err, repo.ErrTranslationNotFound) {
+			return ErrTranslationNotFound
+		}
+		l.WithError(err).Error("error when update translation")
+		return fmt.Errorf("error when update translation: %w", ErrUpdateTranslation)
+	}
+
+	l.WithField("lang", t.Lang).Info("update translation was successfully")
+	return nil
+}
+
+// alignTranslation aligns the translation with the lyrics, which are locked
+// until the translation is saved, and remembers the sections it was made for.
+func (s *Service) alignTranslation(ctx context.Context, t *domain.Translation) error {
+	song, err := s.repo.GetSong(ctx, &t.SongID, true)
+	if err != nil {
+		return err
+	}
+	err = t.Text.AlignTo(song.Text)
+	if err != nil {
+		return err
+	}
+	t.Sources = song.Text.SourceHashes()
+	return nil
+}
+
+// GetTranslations returns the translations of the song ordered by the language.
+func (s *Service) GetTranslations(ctx context.Context, id *uuid.UUID) ([]domain.Translation, error) {
+	l := s.log.WithField("service_method", "GetTranslations")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return nil, ErrIDIsNil
+	}
+
+	_, err := s.repo.GetSong(ctx, id, false)
+	if err != nil {
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return nil, ErrSongNotFound
+		}
+		l.WithError(err).Error("error when getSong")
+		return nil, fmt.Errorf("error when getSong: %w", ErrGetSong)
+	}
+
+	translations, err := s.repo.GetTranslations(ctx, *id)
+	if err != nil {
+		l.WithError(err).Error("error when getTranslations")
+		return nil, fmt.Errorf("error when getTranslations: %w", ErrGetTranslation)
+	}
+
+	l.Info("the translations was found successfully")
+	return translations, nil
+}
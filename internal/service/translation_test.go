@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_CreateTranslation() {
+	ctx := context.Background()
+	id := uuid.New()
+	song := &domain.Song{
+		ID: id,
+		Text: domain.SongText{
+			{Type: domain.Verse, Text: "I wanna hold 'em like they do in Texas, please"},
+			{Type: domain.Chorus, Text: "Can't read my, can't read my"},
+		},
+	}
+	translation := func(text ...domain.SongItem) *domain.Translation {
+		return &domain.Translation{SongID: id, Lang: "ru", Text: text}
+	}
+	aligned := translation(
+		domain.SongItem{Type: domain.Verse, Text: "Я хочу держать их, как в Техасе"},
+		domain.SongItem{Type: domain.Chorus, Text: "Не прочесть, не прочесть"},
+	)
+	aligned.Sources = song.Text.SourceHashes()
+
+	tests := []struct {
+		name        string
+		translation *domain.Translation
+		err         error
+		calls       func()
+	}{
+		{
+			name:        "translation equal nil",
+			translation: nil,
+			err:         ErrTranslationIsNil,
+			calls:       func() {},
+		},
+		{
+			name:        "song not found",
+			translation: translation(domain.SongItem{Text: "Я хочу"}),
+			err:         ErrSongNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(nil, repo.ErrSongNotFound)
+			},
+		},
+		{
+			name:        "sections don't match",
+			translation: translation(domain.SongItem{Text: "Я хочу"}),
+			err:         domain.ErrTranslationMismatch,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+			},
+		},
+		{
+			name: "types don't match",
+			translation: translation(
+				domain.SongItem{Type: domain.Chorus, Text: "Я хочу держать их, как в Техасе"},
+				domain.SongItem{Text: "Не прочесть, не прочесть"},
+			),
+			err: domain.ErrTranslationMismatch,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+			},
+		},
+		{
+			name: "translation exists",
+			translation: translation(
+				domain.SongItem{Text: "Я хочу держать их, как в Техасе"},
+				domain.SongItem{Text: "Не прочесть, не прочесть"},
+			),
+			err: ErrTranslationExists,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+				s.repo.EXPECT().CreateTranslation(ctx, aligned).Return(repo.ErrTranslationExists)
+			},
+		},
+		{
+			name: "create translation",
+			translation: translation(
+				domain.SongItem{Text: "Я хочу держать их, как в Техасе"},
+				domain.SongItem{Type: domain.Chorus, Text: "Не прочесть, не прочесть"},
+			),
+			err: nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+				s.repo.EXPECT().CreateTranslation(ctx, aligned).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.CreateTranslation(ctx, tt.translation)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_UpdateTranslation() {
+	ctx := context.Background()
+	id := uuid.New()
+	song := &domain.Song{ID: id, Text: domain.SongText{{Type: domain.Verse, Text: "Paranoia is in bloom"}}}
+	translation := func() *domain.Translation {
+		return &domain.Translation{SongID: id, Lang: "ru", Text: domain.SongText{{Text: "Паранойя цветёт"}}}
+	}
+	aligned := &domain.Translation{
+		SongID:  id,
+		Lang:    "ru",
+		Text:    domain.SongText{{Type: domain.Verse, Text: "Паранойя цветёт"}},
+		Sources: song.Text.SourceHashes(),
+	}
+
+	tests := []struct {
+		name  string
+		err   error
+		calls func()
+	}{
+		{
+			name: "translation not found",
+			err:  ErrTranslationNotFound,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+				s.repo.EXPECT().UpdateTranslation(ctx, aligned).Return(repo.ErrTranslationNotFound)
+			},
+		},
+		{
+			name: "error update translation",
+			err:  fmt.Errorf("error when update translation: %w", ErrUpdateTranslation),
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+				s.repo.EXPECT().UpdateTranslation(ctx, aligned).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "update translation",
+			err:  nil,
+			calls: func() {
+				s.expectTx(ctx)
+				s.repo.EXPECT().GetSong(ctx, &id, true).Return(song, nil)
+				s.repo.EXPECT().UpdateTranslation(ctx, aligned).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.UpdateTranslation(ctx, translation())
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_GetTranslations() {
+	ctx := context.Background()
+	id := uuid.New()
+	translations := []domain.Translation{{SongID: id, Lang: "en-GB"}, {SongID: id, Lang: "ru"}}
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		wait  []domain.Translation
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "song not found",
+			id:   &id,
+			err:  ErrSongNotFound,
+			calls: func() {
+				s.repo.EXPECT().GetSong(ctx, &id, false).Return(nil, repo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "get translations",
+			id:   &id,
+			wait: translations,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().GetSong(ctx, &id, false).Return(&domain.Song{ID: id}, nil)
+				s.repo.EXPECT().GetTranslations(ctx, id).Return(translations, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			got, err := s.service.GetTranslations(ctx, tt.id)
+			s.Equal(tt.wait, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
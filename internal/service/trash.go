@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Alina9496/library/internal/domain"
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/google/uuid"
+)
+
+func (s *Service) GetTrash(ctx context.Context, filter *domain.TrashRequest) ([]domain.Song, error) {
+	l := s.log.WithField("service_method", "GetTrash")
+	if filter == nil {
+		l.Debug(ErrFilterIsNil.Error())
+		return nil, ErrFilterIsNil
+	}
+
+	songs, err := s.repo.GetTrash(ctx, filter)
+	if err != nil {
+		l.WithError(err).Error("error when getTrash")
+		return nil, fmt.Errorf("error when getTrash: %w", ErrGetTrash)
+	}
+
+	l.Info("the deleted songs was found successfully")
+	return songs, nil
+}
+
+func (s *Service) Restore(ctx context.Context, id *uuid.UUID) error {
+	l := s.log.WithField("service_method", "Restore")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return ErrIDIsNil
+	}
+
+	err := s.repo.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return ErrSongNotFound
+		}
+		if errors.Is(err, repo.ErrSongExists) {
+			return ErrSongExists
+		}
+		l.WithError(err).Error("error when restore")
+		return fmt.Errorf("error when restore: %w", ErrRestoreSong)
+	}
+
+	l.WithField("id", id).Info("restore song was successfully")
+	return nil
+}
+
+func (s *Service) Purge(ctx context.Context, id *uuid.UUID) error {
+	l := s.log.WithField("service_method", "Purge")
+	if id == nil {
+		l.Debug(ErrIDIsNil.Error())
+		return ErrIDIsNil
+	}
+
+	err := s.repo.Purge(ctx, id)
+	if err != nil {
+		if errors.Is(err, repo.ErrSongNotFound) {
+			return ErrSongNotFound
+		}
+		l.WithError(err).Error("error when purge")
+		return fmt.Errorf("error when purge: %w", ErrPurgeSong)
+	}
+
+	l.WithField("id", id).Info("purge song was successfully")
+	return nil
+}
+
+// PurgeExpired removes songs which stayed in the trash longer than the retention period.
+func (s *Service) PurgeExpired(ctx context.Context) (int64, error) {
+	l := s.log.WithField("service_method", "PurgeExpired")
+	if s.trashRetention <= 0 {
+		return 0, nil
+	}
+
+	count, err := s.repo.PurgeDeletedBefore(ctx, time.Now().Add(-s.trashRetention))
+	if err != nil {
+		l.WithError(err).Error("error when purge expired")
+		return 0, fmt.Errorf("error when purge expired: %w", ErrPurgeSong)
+	}
+
+	if count > 0 {
+		l.WithField("count", count).Info("expired songs was purged successfully")
+	}
+	return count, nil
+}
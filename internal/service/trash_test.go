@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Alina9496/library/internal/repo"
+	"github.com/Alina9496/tool/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+)
+
+func (s *ServiceSuite) Test_Restore() {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "song is not in the trash",
+			id:   &id,
+			err:  ErrSongNotFound,
+			calls: func() {
+				s.repo.EXPECT().Restore(ctx, &id).Return(repo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "live song with the same name exists",
+			id:   &id,
+			err:  ErrSongExists,
+			calls: func() {
+				s.repo.EXPECT().Restore(ctx, &id).Return(repo.ErrSongExists)
+			},
+		},
+		{
+			name: "error restore song",
+			id:   &id,
+			err:  fmt.Errorf("error when restore: %w", ErrRestoreSong),
+			calls: func() {
+				s.repo.EXPECT().Restore(ctx, &id).Return(errors.ErrUnsupported)
+			},
+		},
+		{
+			name: "song was successfully restored",
+			id:   &id,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().Restore(ctx, &id).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.Restore(ctx, tt.id)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_Purge() {
+	ctx := context.Background()
+	id := uuid.New()
+
+	tests := []struct {
+		name  string
+		id    *uuid.UUID
+		err   error
+		calls func()
+	}{
+		{
+			name:  "id equal nil",
+			id:    nil,
+			err:   ErrIDIsNil,
+			calls: func() {},
+		},
+		{
+			name: "song is not in the trash",
+			id:   &id,
+			err:  ErrSongNotFound,
+			calls: func() {
+				s.repo.EXPECT().Purge(ctx, &id).Return(repo.ErrSongNotFound)
+			},
+		},
+		{
+			name: "song was successfully purged",
+			id:   &id,
+			err:  nil,
+			calls: func() {
+				s.repo.EXPECT().Purge(ctx, &id).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			err := s.service.Purge(ctx, tt.id)
+			s.Equal(tt.err, err)
+		})
+	}
+}
+
+func (s *ServiceSuite) Test_PurgeExpired() {
+	ctx := context.Background()
+	retention := 24 * time.Hour
+	purgeBefore := func(count int64, err error) func(context.Context, time.Time) (int64, error) {
+		return func(_ context.Context, before time.Time) (int64, error) {
+			s.WithinDuration(time.Now().Add(-retention), before, time.Minute)
+			return count, err
+		}
+	}
+
+	tests := []struct {
+		name      string
+		retention time.Duration
+		want      int64
+		err       error
+		calls     func()
+	}{
+		{
+			name:      "purge is disabled",
+			retention: 0,
+			want:      0,
+			err:       nil,
+			calls:     func() {},
+		},
+		{
+			name:      "error purge",
+			retention: retention,
+			want:      0,
+			err:       fmt.Errorf("error when purge expired: %w", ErrPurgeSong),
+			calls: func() {
+				s.repo.EXPECT().PurgeDeletedBefore(ctx, gomock.Any()).DoAndReturn(purgeBefore(0, errors.ErrUnsupported))
+			},
+		},
+		{
+			name:      "expired songs were purged",
+			retention: retention,
+			want:      3,
+			err:       nil,
+			calls: func() {
+				s.repo.EXPECT().PurgeDeletedBefore(ctx, gomock.Any()).DoAndReturn(purgeBefore(3, nil))
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.T().Run(tt.name, func(t *testing.T) {
+			tt.calls()
+			service := New(s.repo, s.info, logger.New(""), TrashRetention(tt.retention))
+			got, err := service.PurgeExpired(ctx)
+			s.Equal(tt.want, got)
+			s.Equal(tt.err, err)
+		})
+	}
+}
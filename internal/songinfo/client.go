@@ -0,0 +1,133 @@
+// Package songinfo implements client of the external song info provider.
+package songinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	_defaultTimeout    = 5 * time.Second
+	_defaultRetries    = 2
+	_defaultRetryDelay = 200 * time.Millisecond
+
+	infoPath = "/info"
+)
+
+var (
+	ErrSongNotFound = errors.New("song info not found")
+	ErrBadResponse  = errors.New("bad response from song info provider")
+)
+
+// SongDetail is the provider response.
+type SongDetail struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// Client -.
+type Client struct {
+	http       *http.Client
+	url        string
+	retries    int
+	retryDelay time.Duration
+}
+
+// New -.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		http:       &http.Client{Timeout: _defaultTimeout},
+		url:        baseURL,
+		retries:    _defaultRetries,
+		retryDelay: _defaultRetryDelay,
+	}
+
+	// Custom options
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// GetSongDetail requests song details by group and song name.
+// Network errors and 5xx responses are retried.
+func (c *Client) GetSongDetail(ctx context.Context, group, song string) (*SongDetail, error) {
+	u, err := url.Parse(c.url + infoPath)
+	if err != nil {
+		return nil, fmt.Errorf("songinfo - GetSongDetail - url.Parse: %w", err)
+	}
+	u.RawQuery = url.Values{"group": {group}, "song": {song}}.Encode()
+
+	var detail *SongDetail
+	for attempt := 0; ; attempt++ {
+		detail, err = c.do(ctx, u.String())
+		if err == nil || !retryable(err) || ctx.Err() != nil || attempt >= c.retries {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.retryDelay):
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return detail, nil
+}
+
+func (c *Client) do(ctx context.Context, u string) (*SongDetail, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("songinfo - do - http.NewRequest: %w", err)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, &retryError{err: fmt.Errorf("songinfo - do - http.Do: %w", err)}
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrSongNotFound
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return nil, &retryError{err: fmt.Errorf("%w: status %d", ErrBadResponse, resp.StatusCode)}
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("%w: status %d", ErrBadResponse, resp.StatusCode)
+	}
+
+	var detail SongDetail
+	err = json.NewDecoder(resp.Body).Decode(&detail)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadResponse, err)
+	}
+
+	return &detail, nil
+}
+
+type retryError struct {
+	err error
+}
+
+func (e *retryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *retryError) Unwrap() error {
+	return e.err
+}
+
+func retryable(err error) bool {
+	var re *retryError
+	return errors.As(err, &re)
+}
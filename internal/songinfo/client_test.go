@@ -0,0 +1,106 @@
+package songinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newStub(t *testing.T, failures int32, status int) (*httptest.Server, *int32) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.URL.Path != infoPath || r.URL.Query().Get("group") != "Muse" || r.URL.Query().Get("song") != "Supermassive Black Hole" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if n <= failures {
+			w.WriteHeader(status)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(SongDetail{
+			ReleaseDate: "16.07.2006",
+			Text:        "Ooh baby, don't you know I suffer?\n\nOoh\nYou caught me under false pretenses",
+			Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+func TestClient_GetSongDetail(t *testing.T) {
+	want := &SongDetail{
+		ReleaseDate: "16.07.2006",
+		Text:        "Ooh baby, don't you know I suffer?\n\nOoh\nYou caught me under false pretenses",
+		Link:        "https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	}
+
+	tests := []struct {
+		name      string
+		group     string
+		failures  int32
+		status    int
+		retries   int
+		want      *SongDetail
+		wantErr   error
+		wantCalls int32
+	}{
+		{
+			name:      "success",
+			group:     "Muse",
+			want:      want,
+			wantCalls: 1,
+		},
+		{
+			name:      "not found is not retried",
+			group:     "Unknown",
+			retries:   2,
+			wantErr:   ErrSongNotFound,
+			wantCalls: 1,
+		},
+		{
+			name:      "server error is retried",
+			group:     "Muse",
+			failures:  2,
+			status:    http.StatusBadGateway,
+			retries:   2,
+			want:      want,
+			wantCalls: 3,
+		},
+		{
+			name:      "retries exhausted",
+			group:     "Muse",
+			failures:  5,
+			status:    http.StatusInternalServerError,
+			retries:   1,
+			wantErr:   ErrBadResponse,
+			wantCalls: 2,
+		},
+		{
+			name:      "bad request is not retried",
+			group:     "Muse",
+			failures:  5,
+			status:    http.StatusBadRequest,
+			retries:   2,
+			wantErr:   ErrBadResponse,
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, calls := newStub(t, tt.failures, tt.status)
+			c := New(srv.URL, Retries(tt.retries), RetryDelay(time.Millisecond), Timeout(time.Second))
+
+			got, err := c.GetSongDetail(context.Background(), tt.group, "Supermassive Black Hole")
+			assert.Equal(t, tt.want, got)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(calls))
+		})
+	}
+}
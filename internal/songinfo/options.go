@@ -0,0 +1,27 @@
+package songinfo
+
+import "time"
+
+// Option -.
+type Option func(*Client)
+
+// Timeout -.
+func Timeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.http.Timeout = timeout
+	}
+}
+
+// Retries -.
+func Retries(retries int) Option {
+	return func(c *Client) {
+		c.retries = retries
+	}
+}
+
+// RetryDelay -.
+func RetryDelay(delay time.Duration) Option {
+	return func(c *Client) {
+		c.retryDelay = delay
+	}
+}
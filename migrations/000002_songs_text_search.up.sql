@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS songs_text_search_idx ON songs
+    USING GIN (to_tsvector('simple', jsonb_path_query_array(text, '$[*].text')));
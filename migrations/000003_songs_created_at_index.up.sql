@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS songs_created_at_id_idx ON songs (created_at, id);
@@ -0,0 +1,41 @@
+CREATE OR REPLACE FUNCTION normalize_name(value text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT lower(btrim(regexp_replace(value, '\s+', ' ', 'g'))) $$;
+
+CREATE TABLE IF NOT EXISTS artists(
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    name text not null,
+    description text not null DEFAULT '',
+    created_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp not null DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS artists_name_key ON artists (normalize_name(name));
+
+-- backfill artists from the free-text executor, the earliest spelling wins
+INSERT INTO artists (name)
+SELECT DISTINCT ON (normalize_name(executor)) btrim(regexp_replace(executor, '\s+', ' ', 'g'))
+FROM songs
+ORDER BY normalize_name(executor), created_at
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS artist_id uuid REFERENCES artists(id) ON DELETE RESTRICT;
+
+UPDATE songs s
+SET artist_id = a.id,
+    executor = a.name
+FROM artists a
+WHERE normalize_name(s.executor) = normalize_name(a.name);
+
+ALTER TABLE songs ALTER COLUMN artist_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS songs_artist_id_idx ON songs (artist_id);
+
+CREATE TABLE IF NOT EXISTS song_featured_artists(
+    song_id uuid not null REFERENCES songs(id) ON DELETE CASCADE,
+    artist_id uuid not null REFERENCES artists(id) ON DELETE RESTRICT,
+    position int not null,
+    PRIMARY KEY (song_id, artist_id)
+);
+
+CREATE INDEX IF NOT EXISTS song_featured_artists_artist_id_idx ON song_featured_artists (artist_id);
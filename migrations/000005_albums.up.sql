@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS albums(
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    title text not null,
+    artist_id uuid not null REFERENCES artists(id) ON DELETE RESTRICT,
+    release_date date not null,
+    created_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp not null DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS albums_artist_id_idx ON albums (artist_id);
+
+CREATE TABLE IF NOT EXISTS album_tracks(
+    album_id uuid not null REFERENCES albums(id) ON DELETE CASCADE,
+    song_id uuid not null REFERENCES songs(id) ON DELETE CASCADE,
+    position int not null CHECK (position > 0),
+    PRIMARY KEY (album_id, position),
+    UNIQUE (album_id, song_id)
+);
+
+CREATE INDEX IF NOT EXISTS album_tracks_song_id_idx ON album_tracks (song_id);
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS song_revisions(
+    song_id uuid not null REFERENCES songs(id) ON DELETE CASCADE,
+    revision int not null CHECK (revision > 0),
+    name text not null,
+    executor text not null,
+    artist_id uuid not null,
+    featured_artist_ids uuid[] not null DEFAULT '{}',
+    text jsonb not null,
+    link text not null,
+    release_date date not null,
+    created_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (song_id, revision)
+);
+
+-- the current state of existing songs becomes their first revision
+INSERT INTO song_revisions (song_id, revision, name, executor, artist_id, featured_artist_ids, text, link, release_date, created_at)
+SELECT s.id, 1, s.name, s.executor, s.artist_id,
+    ARRAY(SELECT fa.artist_id FROM song_featured_artists fa WHERE fa.song_id = s.id ORDER BY fa.position),
+    s.text, s.link, s.release_date, s.updated_at
+FROM songs s
+ON CONFLICT DO NOTHING;
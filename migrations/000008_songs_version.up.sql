@@ -0,0 +1 @@
+ALTER TABLE songs ADD COLUMN IF NOT EXISTS version int not null DEFAULT 1;
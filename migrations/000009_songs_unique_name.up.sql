@@ -0,0 +1,24 @@
+-- the index can't be built over duplicate live songs, they have to be resolved
+-- by hand first, scripts/dedupe_songs.sql lists them. The failure leaves
+-- version 9 dirty, migrate force 8 clears it once the duplicates are gone.
+DO $$
+DECLARE
+    duplicates bigint;
+BEGIN
+    SELECT count(*) INTO duplicates
+    FROM (
+        SELECT 1
+        FROM songs
+        WHERE deleted_at IS NULL
+        GROUP BY normalize_name(executor), normalize_name(name)
+        HAVING count(*) > 1
+    ) d;
+
+    IF duplicates > 0 THEN
+        RAISE EXCEPTION '% group and name pairs are shared by several live songs', duplicates
+            USING HINT = 'Resolve the duplicates listed by scripts/dedupe_songs.sql, then mark the database clean with migrate force 8 and run the migrations again.';
+    END IF;
+END $$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS songs_executor_name_key ON songs (normalize_name(executor), normalize_name(name))
+    WHERE deleted_at IS NULL;
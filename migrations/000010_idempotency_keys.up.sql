@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys(
+    key text PRIMARY KEY,
+    request_hash text not null,
+    status int not null DEFAULT 0,
+    response bytea,
+    created_at timestamp not null DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_created_at_idx ON idempotency_keys (created_at);
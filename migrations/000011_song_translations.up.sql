@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS song_translations(
+    song_id uuid not null REFERENCES songs(id) ON DELETE CASCADE,
+    lang text not null,
+    text jsonb not null,
+    created_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (song_id, lang)
+);
@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS songs_release_date_idx ON songs (release_date, id) WHERE deleted_at IS NULL;
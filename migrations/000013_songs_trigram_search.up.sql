@@ -0,0 +1,13 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+-- unaccent is only stable as its dictionary can change, naming the dictionary
+-- makes the wrapper usable in indexes
+CREATE OR REPLACE FUNCTION search_name(value text) RETURNS text
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$ SELECT normalize_name(public.unaccent('public.unaccent'::regdictionary, value)) $$;
+
+CREATE INDEX IF NOT EXISTS songs_name_trgm_idx ON songs
+    USING GIN (search_name(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS songs_executor_trgm_idx ON songs
+    USING GIN (search_name(executor) gin_trgm_ops) WHERE deleted_at IS NULL;
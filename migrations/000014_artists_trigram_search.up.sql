@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS artists_name_trgm_idx ON artists
+    USING GIN (search_name(name) gin_trgm_ops);
@@ -0,0 +1,3 @@
+-- the token of the request holding the key, a request whose lease has
+-- expired can't complete or release the key taken over by a retry
+ALTER TABLE idempotency_keys ADD COLUMN IF NOT EXISTS owner uuid;
@@ -0,0 +1,3 @@
+-- hashes of the original sections each translation was made for, a section
+-- changed since then is shown in the original
+ALTER TABLE song_translations ADD COLUMN IF NOT EXISTS sources text[];
@@ -1,18 +1,169 @@
 package v1
 
 type SongItem struct {
-	Type string `json:"type"`
-	Text string `json:"text"`
+	Type  string `json:"type"`
+	Label string `json:"label,omitempty"`
+	Text  string `json:"text"`
 }
 
 type Song struct {
-	Text        []SongItem `json:"text,omitempty"`
-	Name        string     `json:"name"`
-	Group       string     `json:"group"`
-	ReleaseDate string     `json:"release_date"`
-	Link        string     `json:"link"`
+	ID                string     `json:"id,omitempty"`
+	Text              []SongItem `json:"text,omitempty"`
+	Name              string     `json:"name"`
+	Group             string     `json:"group"`
+	ArtistID          string     `json:"artist_id,omitempty"`
+	AlbumID           string     `json:"album_id,omitempty"`
+	FeaturedArtistIDs []string   `json:"featured_artist_ids,omitempty"`
+	ReleaseDate       string     `json:"release_date,omitempty"`
+	Link              string     `json:"link,omitempty"`
+	Version           int        `json:"version,omitempty"`
+	CreatedAt         string     `json:"created_at,omitempty"`
+	UpdatedAt         string     `json:"updated_at,omitempty"`
+}
+
+type DeletedSong struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Group       string `json:"group"`
+	ReleaseDate string `json:"release_date"`
+	Link        string `json:"link"`
+	DeletedAt   string `json:"deleted_at"`
+}
+
+type SongRevision struct {
+	Revision  int    `json:"revision"`
+	CreatedAt string `json:"created_at"`
+	Song      Song   `json:"song"`
+}
+
+type Artist struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at,omitempty"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+type ArtistPatch struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+type Album struct {
+	ID          string       `json:"id,omitempty"`
+	Title       string       `json:"title"`
+	Artist      string       `json:"artist"`
+	ArtistID    string       `json:"artist_id,omitempty"`
+	ReleaseDate string       `json:"release_date"`
+	TrackIDs    []string     `json:"track_ids,omitempty"`
+	Tracks      []AlbumTrack `json:"tracks,omitempty"`
+	CreatedAt   string       `json:"created_at,omitempty"`
+	UpdatedAt   string       `json:"updated_at,omitempty"`
+}
+
+type AlbumPatch struct {
+	Title       *string  `json:"title"`
+	Artist      *string  `json:"artist"`
+	ArtistID    *string  `json:"artist_id"`
+	ReleaseDate *string  `json:"release_date"`
+	TrackIDs    []string `json:"track_ids"`
+}
+
+type AlbumTrack struct {
+	Position int    `json:"position"`
+	SongID   string `json:"song_id"`
+	Name     string `json:"name"`
+}
+
+type AlbumTracks struct {
+	TrackIDs []string `json:"track_ids"`
+}
+
+type Translation struct {
+	Lang      string     `json:"lang"`
+	Text      []SongItem `json:"text"`
+	CreatedAt string     `json:"created_at,omitempty"`
+	UpdatedAt string     `json:"updated_at,omitempty"`
 }
 
 type RespID struct {
 	ID string `json:"id"`
 }
+
+type MatchedSection struct {
+	Index   int    `json:"index"`
+	Type    string `json:"type"`
+	Snippet string `json:"snippet"`
+}
+
+type SearchResult struct {
+	ID      string          `json:"id"`
+	Name    string          `json:"name"`
+	Group   string          `json:"group"`
+	Rank    float64         `json:"rank"`
+	Section *MatchedSection `json:"section,omitempty"`
+}
+
+type Suggestion struct {
+	Kind  string `json:"kind"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+	Group string `json:"group,omitempty"`
+}
+
+type ImportRow struct {
+	Row    int    `json:"row"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type ImportReport struct {
+	Created   int         `json:"created"`
+	Duplicate int         `json:"duplicate"`
+	Invalid   int         `json:"invalid"`
+	Failed    int         `json:"failed"`
+	Rows      []ImportRow `json:"rows"`
+}
+
+type Chord struct {
+	Line int    `json:"line"`
+	Pos  int    `json:"pos"`
+	Name string `json:"name"`
+}
+
+type TimedWord struct {
+	Start int64  `json:"start"`
+	Text  string `json:"text"`
+}
+
+type TimedLine struct {
+	Start int64       `json:"start"`
+	Words []TimedWord `json:"words,omitempty"`
+}
+
+// ExportSongItem is a section of the lyrics with its chords and timing.
+type ExportSongItem struct {
+	Type   string      `json:"type"`
+	Label  string      `json:"label,omitempty"`
+	Text   string      `json:"text"`
+	Chords []Chord     `json:"chords,omitempty"`
+	Timing []TimedLine `json:"timing,omitempty"`
+}
+
+type ExportSong struct {
+	ID          string           `json:"id"`
+	Group       string           `json:"group"`
+	Name        string           `json:"name"`
+	Link        string           `json:"link"`
+	ReleaseDate string           `json:"release_date"`
+	Text        []ExportSongItem `json:"text"`
+	Version     int              `json:"version"`
+	CreatedAt   string           `json:"created_at"`
+	UpdatedAt   string           `json:"updated_at"`
+}
@@ -0,0 +1,31 @@
+-- Live songs sharing the group and the name ignoring case and extra whitespace,
+-- which block the unique index of migration 000009. Every song after the
+-- earliest one of its pair has to be merged, renamed or deleted by hand.
+-- When migration 000009 has already failed, run migrate force 8 afterwards
+-- so that it is applied again.
+SELECT normalize_name(executor) AS executor, normalize_name(name) AS name,
+    array_agg(id ORDER BY created_at, id) AS ids
+FROM songs
+WHERE deleted_at IS NULL
+GROUP BY normalize_name(executor), normalize_name(name)
+HAVING count(*) > 1
+ORDER BY 1, 2;
+
+-- To keep only the earliest song of each pair, move the rest to the trash.
+-- Trashed songs are purged for good after trash.retention, restore what is
+-- still needed before that.
+--
+-- UPDATE songs
+-- SET deleted_at = CURRENT_TIMESTAMP
+-- WHERE id IN (
+--     SELECT id
+--     FROM (
+--         SELECT id, row_number() OVER (
+--             PARTITION BY normalize_name(executor), normalize_name(name)
+--             ORDER BY created_at, id
+--         ) AS n
+--         FROM songs
+--         WHERE deleted_at IS NULL
+--     ) d
+--     WHERE d.n > 1
+-- );